@@ -1,7 +1,9 @@
 package gotest
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"go.uber.org/mock/gomock"
 )
@@ -26,14 +28,15 @@ import (
 func ExpectThat(t gomock.TestHelper, val any, expected any) bool {
 	t.Helper()
 
-	matcher := AsMatcher(expected)
+	c := configFor(t)
+	matcher := c.configure(AsMatcher(expected))
 
 	ok := matcher.Matches(val)
 	if ok {
 		return true
 	}
 
-	t.Errorf(getExplanation("Expectation", matcher, val))
+	c.fail(t, false, getExplanation("Expectation", matcher, val))
 	return false
 }
 
@@ -44,6 +47,96 @@ func ExpectEq[T any](t gomock.TestHelper, actual T, expected T) bool {
 	return ExpectThat(t, actual, Eq(expected))
 }
 
+// Same as ExpectThat(), but adds a formatted message to the failure output,
+// as with WithMessage(). Useful to tell which case of a table-driven test
+// failed.
+//
+// Example:
+//
+//	for i, tc := range cases {
+//		ExpectThatf(t, Parse(tc.input), tc.want, "case %d (%q)", i, tc.input)
+//	}
+func ExpectThatf(t gomock.TestHelper, val any, expected any, format string, args ...any) bool {
+	t.Helper()
+	return ExpectThat(t, val, WithMessage(expected, format, args...))
+}
+
+// Same as ExpectEq(), but adds a formatted message to the failure output. See
+// ExpectThatf().
+func ExpectEqf[T any](t gomock.TestHelper, actual T, expected T, format string, args ...any) bool {
+	t.Helper()
+	return ExpectThatf(t, actual, Eq(expected), format, args...)
+}
+
+// Same as ExpectThat, but statically typed: `m` must be a matcher for values
+// of the same type as `val`, so type mismatches are caught at compile time.
+//
+// Examples:
+//
+//	ExpectThatT(t, 5, EqT(5))                                // succeeds
+//	ExpectThatT(t, "ab", AsMatcherT[string](HasSubstr("a"))) // succeeds
+//	ExpectThatT(t, 5, EqT("5"))                              // doesn't compile
+func ExpectThatT[T any](t gomock.TestHelper, val T, m MatcherT[T]) bool {
+	t.Helper()
+	return ExpectThat(t, val, Untyped(m))
+}
+
+// Tests each element of `slice` against `matcher`, and reports a separate
+// failure, with its index, for each element that doesn't match. Compared to
+// ExpectThat(t, slice, Each(matcher)), this explains every mismatch in full,
+// which makes it easier to tell which elements of a large slice are wrong.
+//
+// Returns whether all of the elements matched.
+//
+// Example:
+//
+//	ExpectEach(t, resp.Items, Field("Status", "ok"))
+func ExpectEach[T any](t gomock.TestHelper, slice []T, matcher any) bool {
+	t.Helper()
+	c := configFor(t)
+	m := c.configure(AsMatcher(matcher))
+	ok := true
+	for i, v := range slice {
+		if !m.Matches(v) {
+			c.fail(t, false, getExplanation(fmt.Sprintf("Expectation for element %d", i), m, v))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Tests that `err` is nil. This is the same as ExpectThat(t, err, NoError()),
+// so on failure the whole chain of wrapped errors is shown.
+//
+// Example:
+//
+//	f, err := os.Open(path)
+//	ExpectNoError(t, err)
+func ExpectNoError(t gomock.TestHelper, err error) bool {
+	t.Helper()
+	return ExpectThat(t, err, NoError())
+}
+
+// Tests that `err` wraps an error of type T, as found by errors.As(), and
+// returns it for further assertions. If there's no such error, returns the
+// zero value of T and false.
+//
+// Example:
+//
+//	pathErr, ok := ExpectErrorAs[*fs.PathError](t, err)
+//	if ok {
+//		ExpectEq(t, pathErr.Path, "config.yaml")
+//	}
+func ExpectErrorAs[T error](t gomock.TestHelper, err error) (T, bool) {
+	t.Helper()
+	var target T
+	if !ExpectThat(t, err, ErrorAsThat[T](Any())) {
+		return target, false
+	}
+	errors.As(err, &target)
+	return target, true
+}
+
 // Tests that `f()` causes a fatal error that fulfills `errMatcher`.
 //
 // If the function does not panic, or if it panics with an error that doesn't
@@ -64,7 +157,7 @@ func ExpectFatal(t gomock.TestHelper, errMatcher Matcher, f func()) (success boo
 	defer func() {
 		r := recover()
 		if r == nil {
-			t.Errorf("Expected fatal error, but none occurred...")
+			configFor(t).fail(t, false, "Expected fatal error, but none occurred...")
 			success = false
 		} else {
 			success = ExpectThat(t, r, errMatcher)
@@ -74,6 +167,24 @@ func ExpectFatal(t gomock.TestHelper, errMatcher Matcher, f func()) (success boo
 	return true
 }
 
+// Tests that `f()` returns without panicking. If it panics, the panic is
+// recovered, and the test fails with the panic's value and stack trace,
+// rather than crashing.
+//
+// Example:
+//
+//	ExpectNoPanic(t, func() {
+//		handler.ServeHTTP(w, req)
+//	})
+func ExpectNoPanic(t gomock.TestHelper, f func()) bool {
+	t.Helper()
+	if recovered, stack, panicked := recoverWithStack(f); panicked {
+		configFor(t).fail(t, false, explainPanic(recovered, stack))
+		return false
+	}
+	return true
+}
+
 // Same as ExpectThat, but causes the test to immediately terminate on failure.
 //
 // Useful for checking preconditions that would cause fatal errors in further
@@ -89,14 +200,22 @@ func ExpectFatal(t gomock.TestHelper, errMatcher Matcher, f func()) (success boo
 func AssertThat(t gomock.TestHelper, val any, expected any) {
 	t.Helper()
 
-	matcher := AsMatcher(expected)
+	c := configFor(t)
+	matcher := c.configure(AsMatcher(expected))
 
 	ok := matcher.Matches(val)
 	if ok {
 		return
 	}
 
-	t.Fatalf(getExplanation("Assertion", matcher, val))
+	c.fail(t, true, getExplanation("Assertion", matcher, val))
+}
+
+// Same as ExpectThatT(), but causes the test to immediately terminate on
+// failure.
+func AssertThatT[T any](t gomock.TestHelper, val T, m MatcherT[T]) {
+	t.Helper()
+	AssertThat(t, val, Untyped(m))
 }
 
 // Same as ExpectEq(), but causes the test to immediately terminate on failure.
@@ -105,6 +224,114 @@ func AssertEq[T any](t gomock.TestHelper, actual T, expected T) {
 	AssertThat(t, actual, Eq(expected))
 }
 
+// Same as AssertThat(), but adds a formatted message to the failure output.
+// See ExpectThatf().
+func AssertThatf(t gomock.TestHelper, val any, expected any, format string, args ...any) {
+	t.Helper()
+	AssertThat(t, val, WithMessage(expected, format, args...))
+}
+
+// Same as AssertEq(), but adds a formatted message to the failure output. See
+// ExpectThatf().
+func AssertEqf[T any](t gomock.TestHelper, actual T, expected T, format string, args ...any) {
+	t.Helper()
+	AssertThatf(t, actual, Eq(expected), format, args...)
+}
+
+// Same as ExpectNoError(), but causes the test to immediately terminate on
+// failure.
+//
+// Example:
+//
+//	f, err := os.Open(path)
+//	AssertNoError(t, err)
+//	defer f.Close()
+func AssertNoError(t gomock.TestHelper, err error) {
+	t.Helper()
+	AssertThat(t, err, NoError())
+}
+
+// Returns `val` if `err` is nil. Otherwise, causes the test to immediately
+// terminate, as with AssertNoError().
+//
+// Example:
+//
+//	cfg, err := LoadConfig(path)
+//	server := NewServer(Must(t, cfg, err))
+//
+// Go only allows a call with several results as the sole argument of another
+// call, so to unwrap one inline, use Unwrap() instead.
+func Must[T any](t gomock.TestHelper, val T, err error) T {
+	t.Helper()
+	AssertNoError(t, err)
+	return val
+}
+
+// Same as Must(), for use inline with calls that return a value and an error.
+//
+// Example:
+//
+//	cfg := Unwrap(LoadConfig(path))(t)
+func Unwrap[T any](val T, err error) func(t gomock.TestHelper) T {
+	return func(t gomock.TestHelper) T {
+		t.Helper()
+		return Must(t, val, err)
+	}
+}
+
+// Returns the first element of `slice` that fulfills `matcher`, for further
+// assertions. If none does, causes the test to immediately terminate.
+//
+// Example:
+//
+//	alice := FindThat(t, users, Field("Name", "alice"))
+//	ExpectThat(t, alice.Roles, Contains("admin"))
+func FindThat[T any](t gomock.TestHelper, slice []T, matcher any) T {
+	t.Helper()
+	m := AsMatcher(matcher)
+	AssertThat(t, slice, ContainsN(m, Ge(1)))
+	return findFirst(slice, m)
+}
+
+// Same as FindThat(), but also causes the test to immediately terminate if
+// more than one element of `slice` fulfills `matcher`.
+//
+// Example:
+//
+//	primary := GetThat(t, replicas, Field("Primary", true))
+func GetThat[T any](t gomock.TestHelper, slice []T, matcher any) T {
+	t.Helper()
+	m := AsMatcher(matcher)
+	AssertThat(t, slice, ContainsN(m, 1))
+	return findFirst(slice, m)
+}
+
+// Returns the first element of `slice` that fulfills `m`, or the zero value.
+func findFirst[T any](slice []T, m Matcher) T {
+	for _, v := range slice {
+		if m.Matches(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// Same as ExpectErrorAs(), but causes the test to immediately terminate on
+// failure.
+//
+// Example:
+//
+//	pathErr := AssertErrorAs[*fs.PathError](t, err)
+//	ExpectEq(t, pathErr.Path, "config.yaml")
+func AssertErrorAs[T error](t gomock.TestHelper, err error) T {
+	t.Helper()
+	var target T
+	AssertThat(t, err, ErrorAsThat[T](Any()))
+	errors.As(err, &target)
+	return target
+}
+
 // Same as ExpectFatal(), but causes the test to immediately terminate on failure.
 func AssertFatal(t gomock.TestHelper, errMatcher Matcher, f func()) {
 	t.Helper()
@@ -112,7 +339,7 @@ func AssertFatal(t gomock.TestHelper, errMatcher Matcher, f func()) {
 	defer func() {
 		r := recover()
 		if r == nil {
-			t.Fatalf("Asserted fatal error, but none occurred...")
+			configFor(t).fail(t, true, "Asserted fatal error, but none occurred...")
 		} else {
 			AssertThat(t, r, errMatcher)
 		}
@@ -120,6 +347,21 @@ func AssertFatal(t gomock.TestHelper, errMatcher Matcher, f func()) {
 	f()
 }
 
+// Same as ExpectNoPanic(), but causes the test to immediately terminate on
+// failure.
+func AssertNoPanic(t gomock.TestHelper, f func()) {
+	t.Helper()
+	if recovered, stack, panicked := recoverWithStack(f); panicked {
+		configFor(t).fail(t, true, explainPanic(recovered, stack))
+	}
+}
+
+func explainPanic(recovered any, stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	return fmt.Sprintf("Expected no panic, but function panicked with: %v (%T)\n  Stack:\n    %s",
+		recovered, recovered, strings.Join(lines, "\n    "))
+}
+
 func getExplanation(context string, matcher Matcher, val any) string {
 	var e string
 	var useE bool
@@ -129,11 +371,14 @@ func getExplanation(context string, matcher Matcher, val any) string {
 		e, useE = "", false
 	}
 
+	limits := outputLimitsOf(matcher)
+	explanation := fmt.Sprintf("%s failed:\n  Wanted: %s\n  Got: %s",
+		context, matcher.String(), truncate(formatGot(val, matcher), limits))
+	if msg := failureMessageOf(matcher); msg != "" {
+		explanation += fmt.Sprintf("\n  Message: %s", msg)
+	}
 	if useE {
-		return fmt.Sprintf("%s failed:\n  Wanted: %s\n  Got: %s\n  ...where %s",
-			context, matcher.String(), formatGot(val, matcher), e)
-	} else {
-		return fmt.Sprintf("%s failed:\n  Wanted: %s\n  Got: %s",
-			context, matcher.String(), formatGot(val, matcher))
+		explanation += fmt.Sprintf("\n  ...where %s", truncate(e, limits))
 	}
+	return explanation
 }
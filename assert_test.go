@@ -3,6 +3,7 @@ package gotest
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -182,3 +183,132 @@ func TestExpectations(t *testing.T) {
 		expectFatal(t, &r, "Wanted: adheres to a custom condition")
 	})
 }
+
+func TestFormattedVariants(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ExpectThatf(&r, 5, Gt(3), "case %d", 1), true)
+	ExpectEq(t, ExpectEqf(&r, "a", "a", "case %d", 2), true)
+	AssertThatf(&r, 5, Gt(3), "case %d", 3)
+	AssertEqf(&r, "a", "a", "case %d", 4)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, ExpectThatf(&r, 2, Gt(3), "case %d (%q)", 5, "two"), false)
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is greater than 3 (int)",
+		"  Got: 2 (int)",
+		`  Message: case 5 ("two")`,
+	}, "\n"))
+
+	r.Reset()
+	ExpectEq(t, ExpectEqf(&r, 2, 3, "case %d", 6), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("\n  Got: 2 (int)\n  Message: case 6\n  ...where ")))
+
+	r.Reset()
+	AssertThatf(&r, 2, Gt(3), "case %d", 7)
+	AssertEqf(&r, 2, 3, "case %d", 8)
+	ExpectThat(t, r.fatals, ElementsAre(
+		StartsWith("Assertion failed:\n  Wanted: is greater than 3 (int)"),
+		HasSubstr("\n  Got: 2 (int)\n  Message: case 8\n  ...where "),
+	))
+}
+
+func TestMust(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, Must(&r, 5, nil), 5)
+	ExpectEq(t, Unwrap(fmt.Sprint("a"), nil)(&r), "a")
+	ExpectEq(t, r.HasFailures(), false)
+
+	err := fmt.Errorf("loading config: %w", errors.New("file not found"))
+	ExpectEq(t, Must(&r, 5, err), 5)
+	ExpectEq(t, Unwrap("a", err)(&r), "a")
+	ExpectThat(t, r.fatals, ElementsAre(
+		HasSubstr("  Got: loading config: file not found"),
+		HasSubstr("  Got: loading config: file not found"),
+	))
+	ExpectEq(t, r.nonFatals, nil)
+}
+
+func TestExpectNoPanic(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ExpectNoPanic(&r, func() {}), true)
+	AssertNoPanic(&r, func() {})
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, ExpectNoPanic(&r, func() { panic("boom") }), false)
+	AssertNoPanic(&r, func() {
+		var m map[string]int
+		m["a"] = 1
+	})
+	ExpectThat(t, r.nonFatals, ElementsAre(AllOf(
+		StartsWith("Expected no panic, but function panicked with: boom (string)\n  Stack:\n    goroutine "),
+		HasSubstr("\n    github.com/jfmatt/gotest.TestExpectNoPanic."),
+	)))
+	ExpectThat(t, r.fatals, ElementsAre(
+		StartsWith("Expected no panic, but function panicked with: assignment to entry in nil map (runtime.plainError)\n"),
+	))
+
+	// Exiting the goroutine, as with t.FailNow(), isn't a panic.
+	done := make(chan bool)
+	r.Reset()
+	go func() {
+		defer close(done)
+		ExpectNoPanic(&r, runtime.Goexit)
+	}()
+	<-done
+	ExpectEq(t, r.HasFailures(), false)
+}
+
+func TestExplain(t *testing.T) {
+	ok, explanation := Explain(Gt(3), 5)
+	ExpectEq(t, ok, true)
+	ExpectEq(t, explanation, "")
+
+	ok, explanation = Explain(Len(2), "abc")
+	ExpectEq(t, ok, false)
+	ExpectEq(t, explanation, strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has length which is equal to 2 (int)",
+		"  Got: abc (string)",
+		"  ...where length is 3",
+	}, "\n"))
+
+	// The same as what ExpectThat() reports.
+	r := testReporter{}
+	ExpectThat(&r, "abc", WithMessage("abd", "message"))
+	_, explanation = Explain(WithMessage("abd", "message"), "abc")
+	ExpectEq(t, explanation, r.nonFatals[0])
+}
+
+func TestFindThat(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+	users := []user{{"alice", 30}, {"bob", 25}, {"alice", 40}}
+	r := testReporter{}
+	ExpectEq(t, FindThat(&r, users, Field("Name", "alice")), user{"alice", 30})
+	ExpectEq(t, GetThat(&r, users, Field("Name", "bob")), user{"bob", 25})
+	ExpectEq(t, GetThat(&r, users, user{"alice", 40}), user{"alice", 40})
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, FindThat(&r, users, Field("Name", "carol")), user{})
+	ExpectEq(t, GetThat(&r, users, Field("Name", "alice")), user{"alice", 30})
+	ExpectThat(t, r.fatals, ElementsAre(
+		HasSubstr("\n  ...where no elements matched"),
+		HasSubstr("\n  ...where 2 elements matched (0, 2)"),
+	))
+}
+
+func TestExpectEach(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ExpectEach(&r, []int{4, 5, 6}, Gt(3)), true)
+	ExpectEq(t, ExpectEach(&r, []string{}, "a"), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, ExpectEach(&r, []int{4, 1, 6, 2}, Gt(3)), false)
+	ExpectEq(t, r.nonFatals, []string{
+		"Expectation for element 1 failed:\n  Wanted: is greater than 3 (int)\n  Got: 1 (int)",
+		"Expectation for element 3 failed:\n  Wanted: is greater than 3 (int)\n  Got: 2 (int)",
+	})
+}
@@ -0,0 +1,284 @@
+// Package assertify provides functions with the same signatures as testify's
+// assert package, backed by gotest matchers. It's meant for test suites that
+// are migrating from testify: existing assertions can switch to the better
+// failure explanations by changing an import, and can be mixed freely with
+// ExpectThat().
+//
+// Only the most common assertions are provided. Where testify's behavior
+// differs from the corresponding matcher's, the matcher's is used; e.g.,
+// Equal() compares with gotest.Eq(), so protos are compared with proto.Equal
+// semantics rather than field-by-field with reflect.DeepEqual.
+package assertify
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jfmatt/gotest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// Adapts a matcher (or a value, which is compared with gotest.Eq()) for use
+// wherever testify accepts an assertion function, such as in table-driven
+// tests.
+//
+// Example:
+//
+//	tests := []struct {
+//		input  string
+//		assert assert.ValueAssertionFunc
+//	}{
+//		{"ok", assertify.ToTestify(gotest.HasSubstr("success"))},
+//		{"", assert.Empty},
+//	}
+//	for _, tc := range tests {
+//		tc.assert(t, Process(tc.input))
+//	}
+func ToTestify(m any) assert.ValueAssertionFunc {
+	return func(t assert.TestingT, actual any, msgAndArgs ...any) bool {
+		if h, ok := t.(tHelper); ok {
+			h.Helper()
+		}
+		return expectThat(t, actual, m, msgAndArgs)
+	}
+}
+
+// Same as assert.Equal(), using gotest.Eq().
+func Equal(t assert.TestingT, expected, actual any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, actual, gotest.Eq(expected), msgAndArgs)
+}
+
+// Same as assert.NotEqual(), using gotest.Eq().
+func NotEqual(t assert.TestingT, expected, actual any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, actual, gotest.Not(gotest.Eq(expected)), msgAndArgs)
+}
+
+// Same as assert.Nil(), using gotest.Nil().
+func Nil(t assert.TestingT, object any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, object, gotest.Nil(), msgAndArgs)
+}
+
+// Same as assert.NotNil(), using gotest.Nil().
+func NotNil(t assert.TestingT, object any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, object, gotest.Not(gotest.Nil()), msgAndArgs)
+}
+
+// Same as assert.True().
+func True(t assert.TestingT, value bool, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, value, true, msgAndArgs)
+}
+
+// Same as assert.False().
+func False(t assert.TestingT, value bool, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, value, false, msgAndArgs)
+}
+
+// Same as assert.Empty(), using gotest.Empty(). Unlike testify, only strings
+// and containers can be empty; other zero values can be checked with Equal().
+func Empty(t assert.TestingT, object any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, object, gotest.Empty(), msgAndArgs)
+}
+
+// Same as assert.NotEmpty(), using gotest.Empty().
+func NotEmpty(t assert.TestingT, object any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, object, gotest.Not(gotest.Empty()), msgAndArgs)
+}
+
+// Same as assert.Len(), using gotest.Len().
+func Len(t assert.TestingT, object any, length int, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, object, gotest.Len(length), msgAndArgs)
+}
+
+// Same as assert.Contains(): tests that a string contains a substring, a map
+// contains a key, or a slice or array contains an element.
+func Contains(t assert.TestingT, s, contains any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, s, containsMatcher(s, contains), msgAndArgs)
+}
+
+// Same as assert.NotContains(). See Contains().
+func NotContains(t assert.TestingT, s, contains any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, s, gotest.Not(containsMatcher(s, contains)), msgAndArgs)
+}
+
+// Same as assert.ElementsMatch(), using gotest.ElementsAreUnordered().
+func ElementsMatch(t assert.TestingT, listA, listB any, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	// As in testify, nil is the same as an empty list.
+	if listA == nil {
+		listA = []any{}
+	}
+	var elements []any
+	if listB != nil {
+		r := reflect.ValueOf(listB)
+		if r.Kind() != reflect.Slice && r.Kind() != reflect.Array {
+			t.Errorf("ElementsMatch: %v (%T) isn't a slice or array", listB, listB)
+			return false
+		}
+		elements = make([]any, r.Len())
+		for i := range elements {
+			elements[i] = r.Index(i).Interface()
+		}
+	}
+	return expectThat(t, listA, gotest.ElementsAreUnordered(elements...), msgAndArgs)
+}
+
+// Same as assert.NoError(), using gotest.NoError().
+func NoError(t assert.TestingT, err error, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, err, gotest.NoError(), msgAndArgs)
+}
+
+// Same as assert.Error(), using gotest.NoError().
+func Error(t assert.TestingT, err error, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, err, gotest.Not(gotest.NoError()), msgAndArgs)
+}
+
+// Same as assert.ErrorIs(), using gotest.ErrorIs().
+func ErrorIs(t assert.TestingT, err, target error, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, err, gotest.ErrorIs(target), msgAndArgs)
+}
+
+// Same as assert.EqualError(), using gotest.ErrorMessage().
+func EqualError(t assert.TestingT, theError error, errString string, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, theError, gotest.ErrorMessage(errString), msgAndArgs)
+}
+
+// Same as assert.ErrorContains(), using gotest.ErrorMessage().
+func ErrorContains(t assert.TestingT, theError error, contains string, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, theError, gotest.ErrorMessage(gotest.HasSubstr(contains)), msgAndArgs)
+}
+
+// Same as assert.JSONEq(), using gotest.JSONEq().
+func JSONEq(t assert.TestingT, expected, actual string, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, actual, gotest.JSONEq(expected), msgAndArgs)
+}
+
+// Same as assert.Panics(), using gotest.Panics().
+func Panics(t assert.TestingT, f assert.PanicTestFunc, msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return expectThat(t, (func())(f), gotest.Panics(), msgAndArgs)
+}
+
+type tHelper interface {
+	Helper()
+}
+
+func containsMatcher(s, contains any) gotest.Matcher {
+	if _, ok := s.(string); ok {
+		if substr, ok := contains.(string); ok {
+			return gotest.HasSubstr(substr)
+		}
+	}
+	if reflect.ValueOf(s).Kind() == reflect.Map {
+		return gotest.HasKey(contains)
+	}
+	return gotest.Contains(contains)
+}
+
+// Runs gotest.ExpectThat() with the testify-style message in `msgAndArgs`, if
+// any.
+func expectThat(t assert.TestingT, actual, expected any, msgAndArgs []any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	m := gotest.AsMatcher(expected)
+	if msg := message(msgAndArgs); msg != "" {
+		m = gotest.WithMessage(m, "%s", msg)
+	}
+	return gotest.ExpectThat(testHelper(t), actual, m)
+}
+
+// Formats a message the way testify does: either a single value, which is
+// used verbatim if it's a string, or a format string followed by its
+// arguments.
+func message(msgAndArgs []any) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		if msg, ok := msgAndArgs[0].(string); ok {
+			return msg
+		}
+		return fmt.Sprintf("%+v", msgAndArgs[0])
+	}
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return fmt.Sprintf("%+v", msgAndArgs[0])
+}
+
+// Returns `t` as a gomock.TestHelper, which it usually already is, e.g. when
+// it's a *testing.T.
+func testHelper(t assert.TestingT) gomock.TestHelper {
+	if h, ok := t.(gomock.TestHelper); ok {
+		return h
+	}
+	return errorfOnly{t}
+}
+
+// Adapts a TestingT that only has Errorf(). Only non-fatal assertions are
+// made, so Fatalf() is never called.
+type errorfOnly struct {
+	assert.TestingT
+}
+
+func (t errorfOnly) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+}
+
+func (t errorfOnly) Helper() {}
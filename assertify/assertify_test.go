@@ -0,0 +1,105 @@
+package assertify_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	. "github.com/jfmatt/gotest"
+	"github.com/jfmatt/gotest/assertify"
+	"github.com/stretchr/testify/assert"
+)
+
+// A TestingT with only the method testify requires.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+type point struct {
+	x, y int
+}
+
+func TestPassing(t *testing.T) {
+	f := &fakeT{}
+	assertify.Equal(f, point{1, 2}, point{1, 2})
+	assertify.NotEqual(f, point{1, 2}, point{2, 1})
+	assertify.Nil(f, nil)
+	assertify.NotNil(f, &point{})
+	assertify.True(f, true)
+	assertify.False(f, false)
+	assertify.Empty(f, "")
+	assertify.NotEmpty(f, []int{1})
+	assertify.Len(f, map[string]int{"a": 1}, 1)
+	assertify.Contains(f, "hello", "ell")
+	assertify.Contains(f, map[string]int{"a": 1}, "a")
+	assertify.Contains(f, []int{1, 2}, 2)
+	assertify.NotContains(f, []int{1, 2}, 3)
+	assertify.ElementsMatch(f, []int{1, 2, 3}, []int{3, 1, 2})
+	assertify.ElementsMatch(f, []int{}, nil)
+	assertify.ElementsMatch(f, nil, []string(nil))
+	assertify.NoError(f, nil)
+	assertify.Error(f, errors.New("oops"))
+	assertify.ErrorIs(f, fmt.Errorf("reading: %w", fs.ErrNotExist), fs.ErrNotExist)
+	assertify.EqualError(f, errors.New("oops"), "oops")
+	assertify.ErrorContains(f, errors.New("oops"), "oo")
+	assertify.JSONEq(f, `{"a": 1, "b": 2}`, `{"b":2,"a":1}`)
+	assertify.Panics(f, func() { panic("boom") })
+	ExpectThat(t, f.errors, Empty())
+}
+
+func TestFailing(t *testing.T) {
+	f := &fakeT{}
+	ExpectEq(t, assertify.Contains(f, "hello", "xyz", "greeting for %s", "alice"), false)
+	ExpectEq(t, f.errors, []string{strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has substring 'xyz'",
+		"  Got: hello (string)",
+		"  Message: greeting for alice",
+	}, "\n")})
+
+	f = &fakeT{}
+	ExpectEq(t, assertify.Len(f, []int{1, 2}, 3), false)
+	ExpectEq(t, assertify.NotContains(f, map[string]int{"a": 1}, "a"), false)
+	ExpectEq(t, assertify.ElementsMatch(f, []int{1, 2}, 5), false)
+	ExpectEq(t, assertify.Error(f, nil, 42), false)
+	ExpectThat(t, f.errors, ElementsAre(
+		HasSubstr("Wanted: has length which is equal to 3 (int)"),
+		HasSubstr("Wanted: not(has a key which is equal to a (string))"),
+		"ElementsMatch: 5 (int) isn't a slice or array",
+		HasSubstr("Message: 42"),
+	))
+
+	// A single message is used verbatim, as in testify.
+	f = &fakeT{}
+	ExpectEq(t, assertify.Equal(f, 1, 2, "100% broken"), false)
+	ExpectEq(t, assertify.ElementsMatch(f, []int{1}, nil), false)
+	ExpectThat(t, f.errors, ElementsAre(
+		HasSubstr("  Message: 100% broken"),
+		HasSubstr("Wanted: has elements matching (in any order) []"),
+	))
+
+	// Unexported fields of the caller's types are compared.
+	f = &fakeT{}
+	ExpectEq(t, assertify.Equal(f, point{1, 2}, point{1, 3}), false)
+	ExpectThat(t, f.errors, ElementsAre(ContainsRegex(`\+.*y: 3`)))
+}
+
+func TestToTestify(t *testing.T) {
+	var check assert.ValueAssertionFunc = assertify.ToTestify(HasSubstr("ell"))
+	ExpectEq(t, check(t, "hello"), true)
+
+	f := &fakeT{}
+	ExpectEq(t, assertify.ToTestify(Gt(5))(f, 3, "too small"), false)
+	ExpectEq(t, f.errors, []string{strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is greater than 5 (int)",
+		"  Got: 3 (int)",
+		"  Message: too small",
+	}, "\n")})
+}
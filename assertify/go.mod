@@ -0,0 +1,23 @@
+module github.com/jfmatt/gotest/assertify
+
+go 1.23.1
+
+require (
+	github.com/jfmatt/gotest v0.0.0-20261018004104-31e6d4806e77
+	github.com/stretchr/testify v1.10.0
+	go.uber.org/mock v0.5.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/protobuf v1.36.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Builds against the root module in this repository. Replace directives only
+// apply within this module, so users get the version required above.
+replace github.com/jfmatt/gotest => ../
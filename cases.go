@@ -0,0 +1,65 @@
+package gotest
+
+import "testing"
+
+// A case of a table-driven test. See Cases().
+type Case[In any] struct {
+	// Names the case's subtest.
+	Name string
+	// Passed to the function under test.
+	In In
+	// What the result should fulfill: a matcher, or a value to compare with
+	// Eq(). To explain a mismatched struct field by field, use FieldsMatch().
+	Want any
+	// If set, called before the function under test, in the case's subtest.
+	Setup func(t *testing.T)
+}
+
+// Configures Cases().
+type CasesOption func(*casesConfig)
+
+type casesConfig struct {
+	parallel bool
+}
+
+// Runs the cases of Cases() in parallel with each other, with t.Parallel().
+func InParallel() CasesOption {
+	return func(c *casesConfig) {
+		c.parallel = true
+	}
+}
+
+// Runs a table-driven test: for each of `cases`, runs a subtest that calls
+// `f` with the case's input, and expects the result to fulfill the case's
+// Want. A failure is reported in the subtest named for the case, with the
+// matcher's explanation.
+//
+// Example:
+//
+//	Cases(t, func(t *testing.T, in string) int {
+//		n, err := strconv.Atoi(in)
+//		AssertNoError(t, err)
+//		return n
+//	}, []Case[string]{
+//		{Name: "zero", In: "0", Want: 0},
+//		{Name: "negative", In: "-12", Want: Lt(0)},
+//	}, InParallel())
+func Cases[In, Out any](t *testing.T, f func(t *testing.T, in In) Out, cases []Case[In], opts ...CasesOption) {
+	t.Helper()
+	var config casesConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			t.Helper()
+			if config.parallel {
+				t.Parallel()
+			}
+			if c.Setup != nil {
+				c.Setup(t)
+			}
+			ExpectThat(t, f(t, c.In), c.Want)
+		})
+	}
+}
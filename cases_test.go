@@ -0,0 +1,40 @@
+package gotest
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCases(t *testing.T) {
+	var mu sync.Mutex
+	var log []string
+	record := func(entry string) {
+		mu.Lock()
+		defer mu.Unlock()
+		log = append(log, entry)
+	}
+	atoi := func(t *testing.T, in string) int {
+		record(t.Name())
+		n, err := strconv.Atoi(in)
+		AssertNoError(t, err)
+		return n
+	}
+
+	Cases(t, atoi, []Case[string]{
+		{Name: "zero", In: "0", Want: 0},
+		{Name: "negative", In: "-12", Want: Lt(0), Setup: func(t *testing.T) { record("setup " + t.Name()) }},
+	})
+	ExpectEq(t, log, []string{"TestCases/zero", "setup TestCases/negative", "TestCases/negative"})
+
+	log = nil
+	t.Run("parallel", func(t *testing.T) {
+		Cases(t, atoi, []Case[string]{
+			{Name: "one", In: "1", Want: 1},
+			{Name: "two", In: "2", Want: Gt(1)},
+		}, InParallel())
+		// Parallel subtests only run once this function returns.
+		ExpectEq(t, log, nil)
+	})
+	ExpectThat(t, log, ElementsAreUnordered("TestCases/parallel/one", "TestCases/parallel/two"))
+}
@@ -0,0 +1,187 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Matches channels that have a value immediately available, which fulfills
+// `inner`. The value is received from the channel, so it's no longer
+// available afterwards.
+//
+// Examples:
+//
+//	ExpectThat(t, events, Receives("started"))
+//	ExpectThat(t, errs, Receives(Not(Nil())))
+//	ExpectThat(t, results, Receives(Field("Status", "ok")))
+func Receives(inner any) Matcher {
+	return &receiveMatcher{inner: AsMatcher(inner)}
+}
+
+// Matches channels that deliver a value fulfilling `inner` within `timeout`.
+// The value is received from the channel, so it's no longer available
+// afterwards.
+//
+// Examples:
+//
+//	ExpectThat(t, done, ReceivesWithin(time.Second, true))
+//	ExpectThat(t, responses, ReceivesWithin(100*time.Millisecond, HasSubstr("pong")))
+func ReceivesWithin(timeout time.Duration, inner any) Matcher {
+	return &receiveMatcher{inner: AsMatcher(inner), timeout: timeout, wait: true}
+}
+
+// Matches channels that have been closed and drained, such that receiving
+// from them immediately returns the zero value.
+//
+// If the channel has a value available, it's received (and no longer
+// available afterwards), and the channel doesn't match.
+//
+// Examples:
+//
+//	close(ch)
+//	ExpectThat(t, ch, IsClosed())
+//	ExpectThat(t, make(chan int), Not(IsClosed()))
+func IsClosed() Matcher {
+	return &closedMatcher{}
+}
+
+// The outcome of receiving from a channel.
+type receipt struct {
+	ch      any
+	value   any
+	status  receiptStatus
+	problem string
+}
+
+type receiptStatus int
+
+const (
+	received receiptStatus = iota
+	nothingAvailable
+	channelClosed
+	notReceivable
+)
+
+// Receives from the channel `x`, if it is one. If `wait` is true, waits up to
+// `timeout` for a value; otherwise, only takes a value that's immediately
+// available.
+func receive(x any, wait bool, timeout time.Duration) receipt {
+	r := reflect.ValueOf(x)
+	if r.Kind() != reflect.Chan {
+		return receipt{ch: x, status: notReceivable, problem: fmt.Sprintf("type %T isn't a channel", x)}
+	}
+	if r.Type().ChanDir()&reflect.RecvDir == 0 {
+		return receipt{ch: x, status: notReceivable, problem: fmt.Sprintf("type %T can't be received from", x)}
+	}
+
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: r}}
+	if wait {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(timeout))})
+	} else {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+	chosen, value, ok := reflect.Select(cases)
+	switch {
+	case chosen != 0:
+		return receipt{ch: x, status: nothingAvailable}
+	case !ok:
+		return receipt{ch: x, status: channelClosed}
+	default:
+		return receipt{ch: x, value: value.Interface(), status: received}
+	}
+}
+
+// Receiving is destructive, so channel matchers remember the result of the
+// last receive. That way, explaining a failure describes the value that
+// Matches() saw, rather than receiving another one.
+type lastReceipt struct {
+	mu   sync.Mutex
+	last *receipt
+}
+
+func (l *lastReceipt) record(r receipt) receipt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.last = &r
+	return r
+}
+
+// Returns the receipt recorded for the channel `x`, if any.
+func (l *lastReceipt) forChannel(x any) (receipt, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.last == nil || reflect.ValueOf(x).Kind() != reflect.Chan || l.last.ch != x {
+		return receipt{}, false
+	}
+	return *l.last, true
+}
+
+type receiveMatcher struct {
+	lastReceipt
+	inner   Matcher
+	timeout time.Duration
+	wait    bool
+}
+
+func (m *receiveMatcher) Matches(x any) bool {
+	r := m.record(receive(x, m.wait, m.timeout))
+	return r.status == received && m.inner.Matches(r.value)
+}
+
+func (m *receiveMatcher) String() string {
+	if m.wait {
+		return fmt.Sprintf("receives a value within %s which %s", m.timeout, m.inner.String())
+	}
+	return fmt.Sprintf("receives a value which %s", m.inner.String())
+}
+
+func (m *receiveMatcher) ExplainFailure(x any) (string, bool) {
+	r, ok := m.forChannel(x)
+	if !ok {
+		r = m.record(receive(x, m.wait, m.timeout))
+	}
+	switch r.status {
+	case notReceivable:
+		return r.problem, true
+	case channelClosed:
+		return "channel is closed", true
+	case nothingAvailable:
+		if m.wait {
+			return fmt.Sprintf("no value was received within %s", m.timeout), true
+		}
+		return "no value is available", true
+	}
+	explanation := fmt.Sprintf("received %s", formatGot(r.value, m.inner))
+	explanation = appendExplanation(explanation, m.inner, r.value)
+	return explanation, true
+}
+
+type closedMatcher struct {
+	lastReceipt
+}
+
+func (m *closedMatcher) Matches(x any) bool {
+	return m.record(receive(x, false, 0)).status == channelClosed
+}
+
+func (m *closedMatcher) String() string {
+	return "is a closed channel"
+}
+
+func (m *closedMatcher) ExplainFailure(x any) (string, bool) {
+	r, ok := m.forChannel(x)
+	if !ok {
+		r = m.record(receive(x, false, 0))
+	}
+	switch r.status {
+	case notReceivable:
+		return r.problem, true
+	case nothingAvailable:
+		return "channel is open, with no value available", true
+	case received:
+		return fmt.Sprintf("channel is open, and a value was received: %v (%T)", r.value, r.value), true
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReceives(t *testing.T) {
+	ch := make(chan string, 3)
+	ch <- "started"
+	ch <- "working"
+	ExpectThat(t, ch, Receives("started"))
+	ExpectThat(t, ch, Receives(HasSubstr("work")))
+	ExpectThat(t, ch, Not(Receives(Any())))
+
+	var recvOnly <-chan string = ch
+	ch <- "done"
+	ExpectThat(t, recvOnly, Receives("done"))
+
+	close(ch)
+	ExpectThat(t, ch, Not(Receives(Any())))
+	ExpectThat(t, 12, Not(Receives(Any())))
+	ExpectThat(t, make(chan<- int, 1), Not(Receives(Any())))
+
+	r := testReporter{}
+	ch = make(chan string, 1)
+	ch <- "stopped"
+	ExpectThat(&r, ch, Receives(HasSubstr("start")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: receives a value which has substring 'start'",
+		"  Got: " + formatGot(ch, nil),
+		"  ...where received stopped (string)",
+	}, "\n"))
+	// The value was only received once.
+	ExpectThat(t, ch, Not(Receives(Any())))
+
+	r.Reset()
+	ExpectThat(&r, ch, Receives(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no value is available"))
+
+	r.Reset()
+	close(ch)
+	ExpectThat(&r, ch, Receives(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where channel is closed"))
+
+	r.Reset()
+	ExpectThat(&r, 12, Receives(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't a channel"))
+
+	r.Reset()
+	ExpectThat(&r, make(chan<- int), Receives(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type chan<- int can't be received from"))
+}
+
+func TestReceivesWithin(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- 42
+	}()
+	ExpectThat(t, ch, ReceivesWithin(time.Second, 42))
+	ExpectThat(t, ch, Not(ReceivesWithin(10*time.Millisecond, Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, ch, ReceivesWithin(10*time.Millisecond, Any()))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: receives a value within 10ms which is anything",
+		"  Got: " + formatGot(ch, nil),
+		"  ...where no value was received within 10ms",
+	}, "\n"))
+}
+
+func TestIsClosed(t *testing.T) {
+	ch := make(chan int, 1)
+	ExpectThat(t, ch, Not(IsClosed()))
+	close(ch)
+	ExpectThat(t, ch, IsClosed())
+	ExpectThat(t, 12, Not(IsClosed()))
+
+	r := testReporter{}
+	ch = make(chan int, 1)
+	ExpectThat(&r, ch, IsClosed())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a closed channel",
+		"  Got: " + formatGot(ch, nil),
+		"  ...where channel is open, with no value available",
+	}, "\n"))
+
+	r.Reset()
+	ch <- 5
+	ExpectThat(&r, ch, IsClosed())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where channel is open, and a value was received: 5 (int)"))
+
+	// A closed channel with values left in it isn't drained yet.
+	r.Reset()
+	ch <- 6
+	close(ch)
+	ExpectThat(&r, ch, IsClosed())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where channel is open, and a value was received: 6 (int)"))
+	ExpectThat(t, ch, IsClosed())
+}
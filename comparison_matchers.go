@@ -4,22 +4,33 @@ import (
 	"cmp"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strings"
+	"time"
 )
 
+// Types that can be used as thresholds for Gt() and friends. Numbers of any
+// type - including math/big types - can be compared with each other.
+type ordered interface {
+	cmp.Ordered | *big.Int | *big.Float | *big.Rat | time.Time
+}
+
 // Matches values that are greater than the threshold.
 //
 // Works with any ordered types including:
 //   - All numeric types
 //   - strings (lexicographic comparison)
+//   - *big.Int, *big.Float, and *big.Rat
+//   - time.Time (chronological comparison) and time.Duration
 //
 // Examples:
 //
 //	ExpectThat(t, 5, Gt(3))
 //	ExpectThat(t, 10.5, Gt(10.0))
 //	ExpectThat(t, "banana", Gt("apple"))
-func Gt[T cmp.Ordered](threshold T) Matcher {
+//	ExpectThat(t, time.Now(), Gt(startTime))
+func Gt[T ordered](threshold T) Matcher {
 	return gtMatcher[T]{threshold}
 }
 
@@ -28,13 +39,16 @@ func Gt[T cmp.Ordered](threshold T) Matcher {
 // Works with any ordered types including:
 //   - All numeric types
 //   - strings (lexicographic comparison)
+//   - *big.Int, *big.Float, and *big.Rat
+//   - time.Time (chronological comparison) and time.Duration
 //
 // Examples:
 //
 //	ExpectThat(t, 3, Lt(5))
 //	ExpectThat(t, 10.0, Lt(10.5))
 //	ExpectThat(t, "apple", Lt("banana"))
-func Lt[T cmp.Ordered](threshold T) Matcher {
+//	ExpectThat(t, elapsed, Lt(time.Second))
+func Lt[T ordered](threshold T) Matcher {
 	return ltMatcher[T]{threshold}
 }
 
@@ -43,12 +57,14 @@ func Lt[T cmp.Ordered](threshold T) Matcher {
 // Works with any ordered types including:
 //   - All numeric types
 //   - strings (lexicographic comparison)
+//   - *big.Int, *big.Float, and *big.Rat
+//   - time.Time (chronological comparison) and time.Duration
 //
 // Examples:
 //
 //	ExpectThat(t, 5, Ge(5))
 //	ExpectThat(t, 10.5, Ge(10.0))
-func Ge[T cmp.Ordered](threshold T) Matcher {
+func Ge[T ordered](threshold T) Matcher {
 	return geMatcher[T]{threshold}
 }
 
@@ -57,20 +73,442 @@ func Ge[T cmp.Ordered](threshold T) Matcher {
 // Works with any ordered types including:
 //   - All numeric types
 //   - strings (lexicographic comparison)
+//   - *big.Int, *big.Float, and *big.Rat
+//   - time.Time (chronological comparison) and time.Duration
 //
 // Examples:
 //
 //	ExpectThat(t, 5, Le(5))
 //	ExpectThat(t, 10.0, Le(10.5))
-func Le[T cmp.Ordered](threshold T) Matcher {
+func Le[T ordered](threshold T) Matcher {
 	return leMatcher[T]{threshold}
 }
 
-type gtMatcher[T cmp.Ordered] struct {
+// Matches values between `lo` and `hi`. By default, both bounds are
+// inclusive; pass ExcludeLow() and/or ExcludeHigh() to exclude them.
+//
+// Values are compared with the same type promotion as Gt() and friends, so
+// mixing numeric types works as expected.
+//
+// Examples:
+//
+//	ExpectThat(t, 5, InRange(1, 10))
+//	ExpectThat(t, 10, InRange(1, 10))
+//	ExpectThat(t, 10, Not(InRange(1, 10, ExcludeHigh())))
+//	ExpectThat(t, 2.5, InRange(1, 3))
+//	ExpectThat(t, "banana", InRange("apple", "cherry"))
+func InRange[T ordered](lo, hi T, opts ...RangeOption) Matcher {
+	bounds := rangeBounds{includeLow: true, includeHigh: true}
+	for _, opt := range opts {
+		opt(&bounds)
+	}
+	return rangeMatcher[T]{lo, hi, bounds}
+}
+
+// Matches values strictly between `lo` and `hi`. Equivalent to
+// InRange(lo, hi, ExcludeLow(), ExcludeHigh()).
+//
+// Examples:
+//
+//	ExpectThat(t, 5, Between(1, 10))
+//	ExpectThat(t, 10, Not(Between(1, 10)))
+func Between[T ordered](lo, hi T) Matcher {
+	return InRange(lo, hi, ExcludeLow(), ExcludeHigh())
+}
+
+// Matches values in the half-open interval [lo, hi) by default - that is,
+// including `lo` but excluding `hi`. Pass IncludeLow(), ExcludeLow(),
+// IncludeHigh(), or ExcludeHigh() to choose the bounds explicitly.
+//
+// Aside from the default bounds, this is the same as InRange().
+//
+// Examples:
+//
+//	ExpectThat(t, 0, Within(0, 10))
+//	ExpectThat(t, 10, Not(Within(0, 10)))
+//	ExpectThat(t, 10, Within(0, 10, IncludeHigh()))
+//	ExpectThat(t, 0.5, Within(0, 1, ExcludeLow(), ExcludeHigh()))
+func Within[T ordered](lo, hi T, opts ...RangeOption) Matcher {
+	bounds := rangeBounds{includeLow: true, includeHigh: false}
+	for _, opt := range opts {
+		opt(&bounds)
+	}
+	return rangeMatcher[T]{lo, hi, bounds}
+}
+
+// Configures the bounds of InRange() and Within().
+type RangeOption func(*rangeBounds)
+
+// Includes the lower bound in the range, so that values equal to it match.
+func IncludeLow() RangeOption {
+	return func(b *rangeBounds) { b.includeLow = true }
+}
+
+// Includes the upper bound in the range, so that values equal to it match.
+func IncludeHigh() RangeOption {
+	return func(b *rangeBounds) { b.includeHigh = true }
+}
+
+// Excludes the lower bound from the range, so that only values strictly
+// greater than it match.
+func ExcludeLow() RangeOption {
+	return func(b *rangeBounds) { b.includeLow = false }
+}
+
+// Excludes the upper bound from the range, so that only values strictly less
+// than it match.
+func ExcludeHigh() RangeOption {
+	return func(b *rangeBounds) { b.includeHigh = false }
+}
+
+type rangeBounds struct {
+	includeLow  bool
+	includeHigh bool
+}
+
+type rangeMatcher[T ordered] struct {
+	lo, hi T
+	bounds rangeBounds
+}
+
+func (r rangeMatcher[T]) String() string {
+	low, high := "(", ")"
+	if r.bounds.includeLow {
+		low = "["
+	}
+	if r.bounds.includeHigh {
+		high = "]"
+	}
+	interval := fmt.Sprintf("%s%s, %s%s", low, formatBound(r.lo), formatBound(r.hi), high)
+	if _, ok := any(r.lo).(time.Time); ok {
+		return "is in " + interval
+	}
+	return fmt.Sprintf("is in %s (%T)", interval, r.lo)
+}
+
+func formatBound(bound any) string {
+	if tm, ok := bound.(time.Time); ok {
+		return tm.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(bound)
+}
+
+// Returns a description of the violated bound, or "" if `x` is in range.
+func (r rangeMatcher[T]) problem(x any) string {
+	canCompare, cmpLow := tryCompare(x, r.lo)
+	if !canCompare {
+		return fmt.Sprintf("value of type %T can't be compared to %T", x, r.lo)
+	}
+	_, cmpHigh := tryCompare(x, r.hi)
+	switch {
+	case cmpLow < 0:
+		return fmt.Sprintf("value is below the lower bound %s", formatBound(r.lo))
+	case cmpLow == 0 && !r.bounds.includeLow:
+		return fmt.Sprintf("value is equal to the excluded lower bound %s", formatBound(r.lo))
+	case cmpHigh > 0:
+		return fmt.Sprintf("value is above the upper bound %s", formatBound(r.hi))
+	case cmpHigh == 0 && !r.bounds.includeHigh:
+		return fmt.Sprintf("value is equal to the excluded upper bound %s", formatBound(r.hi))
+	default:
+		return ""
+	}
+}
+
+func (r rangeMatcher[T]) Matches(x any) bool {
+	return r.problem(x) == ""
+}
+
+func (r rangeMatcher[T]) ExplainFailure(x any) (string, bool) {
+	if problem := r.problem(x); problem != "" {
+		return problem, true
+	}
+	return "", false
+}
+
+// Matches numbers within `tolerance` of `expected`, inclusive. Useful for
+// comparing the results of floating-point computations, which are rarely
+// exactly equal to the expected value.
+//
+// Works with any numeric type; integers are converted to float64 for the
+// comparison. NaN never matches.
+//
+// Examples:
+//
+//	ExpectThat(t, 0.1+0.2, Near(0.3, 1e-9))
+//	ExpectThat(t, 10, Near(10.5, 0.5))
+//	ExpectThat(t, 3.2, Not(Near(3.0, 0.1)))
+func Near(expected, tolerance float64) Matcher {
+	return nearMatcher{expected, tolerance}
+}
+
+type nearMatcher struct {
+	expected  float64
+	tolerance float64
+}
+
+func (n nearMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", n.tolerance, n.expected)
+}
+
+func (n nearMatcher) Matches(x any) bool {
+	actual, ok := asFloat64(x)
+	if !ok {
+		return false
+	}
+	return math.Abs(actual-n.expected) <= n.tolerance
+}
+
+func (n nearMatcher) ExplainFailure(x any) (string, bool) {
+	actual, ok := asFloat64(x)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not a number", x), true
+	}
+	return fmt.Sprintf("difference is %v", math.Abs(actual-n.expected)), true
+}
+
+// Matches numbers whose relative difference from `expected` is at most
+// `relTol` - that is, |actual - expected| <= relTol * |expected|. Useful for
+// values of large or varying magnitudes, where a fixed tolerance as in Near()
+// doesn't make sense.
+//
+// Works with any numeric type; integers are converted to float64 for the
+// comparison. NaN never matches, and if `expected` is zero, only zero
+// matches.
+//
+// Examples:
+//
+//	ExpectThat(t, 1.001e9, RelNear(1e9, 0.01))
+//	ExpectThat(t, 99, RelNear(100, 0.01))
+//	ExpectThat(t, 1.1e9, Not(RelNear(1e9, 0.01)))
+func RelNear(expected, relTol float64) Matcher {
+	return relNearMatcher{expected, relTol}
+}
+
+type relNearMatcher struct {
+	expected float64
+	relTol   float64
+}
+
+func (n relNearMatcher) String() string {
+	return fmt.Sprintf("is within %v%% of %v", n.relTol*100, n.expected)
+}
+
+func (n relNearMatcher) Matches(x any) bool {
+	actual, ok := asFloat64(x)
+	if !ok {
+		return false
+	}
+	return math.Abs(actual-n.expected) <= n.relTol*math.Abs(n.expected)
+}
+
+func (n relNearMatcher) ExplainFailure(x any) (string, bool) {
+	actual, ok := asFloat64(x)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not a number", x), true
+	}
+	return fmt.Sprintf("relative difference is %v",
+		math.Abs(actual-n.expected)/math.Abs(n.expected)), true
+}
+
+// Matches floating-point values that are NaN. Since NaN is never equal to
+// itself, Eq() can't express this.
+//
+// Works with any float kind, including named types like
+// `type Celsius float64`.
+//
+// Examples:
+//
+//	ExpectThat(t, math.NaN(), IsNaN())
+//	ExpectThat(t, float32(math.NaN()), IsNaN())
+//	ExpectThat(t, 1.5, Not(IsNaN()))
+func IsNaN() Matcher {
+	return floatClassMatcher{
+		desc:  "is NaN",
+		check: math.IsNaN,
+	}
+}
+
+// Matches floating-point values that are infinite with the given sign: if
+// sign > 0, +Inf; if sign < 0, -Inf; if sign == 0, either. (This is the same
+// convention as math.IsInf.)
+//
+// Works with any float kind, including named types like
+// `type Celsius float64`.
+//
+// Examples:
+//
+//	ExpectThat(t, math.Inf(1), IsInf(1))
+//	ExpectThat(t, math.Inf(-1), IsInf(0))
+//	ExpectThat(t, math.Inf(-1), Not(IsInf(1)))
+func IsInf(sign int) Matcher {
+	var desc string
+	switch {
+	case sign > 0:
+		desc = "is +Inf"
+	case sign < 0:
+		desc = "is -Inf"
+	default:
+		desc = "is infinite"
+	}
+	return floatClassMatcher{
+		desc:  desc,
+		check: func(f float64) bool { return math.IsInf(f, sign) },
+	}
+}
+
+type floatClassMatcher struct {
+	desc  string
+	check func(float64) bool
+}
+
+func (f floatClassMatcher) String() string {
+	return f.desc
+}
+
+func (f floatClassMatcher) Matches(x any) bool {
+	val := reflect.ValueOf(x)
+	return val.CanFloat() && f.check(val.Float())
+}
+
+func (f floatClassMatcher) ExplainFailure(x any) (string, bool) {
+	if !reflect.ValueOf(x).CanFloat() {
+		return fmt.Sprintf("value is of type %T, not a float", x), true
+	}
+	return "", false
+}
+
+// Matches numbers greater than zero, of any numeric type.
+//
+// Examples:
+//
+//	ExpectThat(t, 5, Positive())
+//	ExpectThat(t, uint8(1), Positive())
+//	ExpectThat(t, 0.0, Not(Positive()))
+func Positive() Matcher {
+	return signMatcher{
+		desc: "is a positive number",
+		check: func(class numClass, f float64) bool {
+			return class != numClassNegativeInt && class != numClassNegativeFloat && f > 0
+		},
+	}
+}
+
+// Matches numbers less than zero, of any numeric type.
+//
+// Examples:
+//
+//	ExpectThat(t, -5, Negative())
+//	ExpectThat(t, -0.5, Negative())
+//	ExpectThat(t, 0, Not(Negative()))
+func Negative() Matcher {
+	return signMatcher{
+		desc: "is a negative number",
+		check: func(class numClass, _ float64) bool {
+			return class == numClassNegativeInt || class == numClassNegativeFloat
+		},
+	}
+}
+
+// Matches numbers not equal to zero, of any numeric type.
+//
+// Examples:
+//
+//	ExpectThat(t, -5, NonZero())
+//	ExpectThat(t, uint(3), NonZero())
+//	ExpectThat(t, 0.0, Not(NonZero()))
+func NonZero() Matcher {
+	return signMatcher{
+		desc: "is a non-zero number",
+		check: func(_ numClass, f float64) bool {
+			return f != 0
+		},
+	}
+}
+
+type signMatcher struct {
+	desc  string
+	check func(class numClass, f float64) bool
+}
+
+func (s signMatcher) String() string {
+	return s.desc
+}
+
+func (s signMatcher) Matches(x any) bool {
+	class := classify(x)
+	if class == numClassNonNumeric {
+		return false
+	}
+	return s.check(class, toFloat64(reflect.ValueOf(x)))
+}
+
+func (s signMatcher) ExplainFailure(x any) (string, bool) {
+	if classify(x) == numClassNonNumeric {
+		return fmt.Sprintf("value is of type %T, not a number", x), true
+	}
+	return "", false
+}
+
+// Matches integers that are evenly divisible by `n`, of any integer type.
+// Panics if `n` is zero.
+//
+// Examples:
+//
+//	ExpectThat(t, 12, DivisibleBy(4))
+//	ExpectThat(t, uint64(4096), DivisibleBy(512))
+//	ExpectThat(t, -9, DivisibleBy(3))
+//	ExpectThat(t, 10, Not(DivisibleBy(3)))
+func DivisibleBy[T integer](n T) Matcher {
+	if n == 0 {
+		panic("DivisibleBy: divisor must be non-zero")
+	}
+	return divisibleMatcher{n}
+}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type divisibleMatcher struct {
+	n any
+}
+
+func (d divisibleMatcher) String() string {
+	return fmt.Sprintf("is divisible by %v", d.n)
+}
+
+// Returns the magnitude of the remainder of dividing `x` by the divisor, or
+// false if `x` isn't an integer.
+func (d divisibleMatcher) remainder(x any) (uint64, bool) {
+	val := reflect.ValueOf(x)
+	if !val.CanInt() && !val.CanUint() {
+		return 0, false
+	}
+	return absUint64(val) % absUint64(reflect.ValueOf(d.n)), true
+}
+
+func (d divisibleMatcher) Matches(x any) bool {
+	rem, ok := d.remainder(x)
+	return ok && rem == 0
+}
+
+func (d divisibleMatcher) ExplainFailure(x any) (string, bool) {
+	rem, ok := d.remainder(x)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not an integer", x), true
+	}
+	return fmt.Sprintf("remainder is %d", rem), true
+}
+
+type gtMatcher[T ordered] struct {
 	threshold T
 }
 
 func (g gtMatcher[T]) String() string {
+	if tm, ok := any(g.threshold).(time.Time); ok {
+		return fmt.Sprintf("is after %s", tm.Format(time.RFC3339Nano))
+	}
 	return fmt.Sprintf("is greater than %v (%T)", g.threshold, g.threshold)
 }
 
@@ -82,11 +520,14 @@ func (g gtMatcher[T]) Matches(x any) bool {
 	return cmpResult > 0 // x > threshold
 }
 
-type ltMatcher[T cmp.Ordered] struct {
+type ltMatcher[T ordered] struct {
 	threshold T
 }
 
 func (l ltMatcher[T]) String() string {
+	if tm, ok := any(l.threshold).(time.Time); ok {
+		return fmt.Sprintf("is before %s", tm.Format(time.RFC3339Nano))
+	}
 	return fmt.Sprintf("is less than %v (%T)", l.threshold, l.threshold)
 }
 
@@ -98,11 +539,14 @@ func (l ltMatcher[T]) Matches(x any) bool {
 	return cmpResult < 0 // x < threshold
 }
 
-type geMatcher[T cmp.Ordered] struct {
+type geMatcher[T ordered] struct {
 	threshold T
 }
 
 func (g geMatcher[T]) String() string {
+	if tm, ok := any(g.threshold).(time.Time); ok {
+		return fmt.Sprintf("is at or after %s", tm.Format(time.RFC3339Nano))
+	}
 	return fmt.Sprintf("is greater than or equal to %v (%T)", g.threshold, g.threshold)
 }
 
@@ -114,11 +558,14 @@ func (g geMatcher[T]) Matches(x any) bool {
 	return cmpResult >= 0 // x >= threshold
 }
 
-type leMatcher[T cmp.Ordered] struct {
+type leMatcher[T ordered] struct {
 	threshold T
 }
 
 func (l leMatcher[T]) String() string {
+	if tm, ok := any(l.threshold).(time.Time); ok {
+		return fmt.Sprintf("is at or before %s", tm.Format(time.RFC3339Nano))
+	}
 	return fmt.Sprintf("is less than or equal to %v (%T)", l.threshold, l.threshold)
 }
 
@@ -147,7 +594,19 @@ const (
 // Returns (canCompare bool, comparisonResult int) where:
 //   - canCompare is false if types are incompatible
 //   - comparisonResult is -1 if actual < threshold, 0 if equal, 1 if actual > threshold
-func tryCompare[T cmp.Ordered](actual any, threshold T) (bool, int) {
+func tryCompare(actual any, threshold any) (bool, int) {
+	if thresholdTime, ok := threshold.(time.Time); ok {
+		actualTime, ok := actual.(time.Time)
+		if !ok {
+			return false, 0
+		}
+		return true, actualTime.Compare(thresholdTime)
+	}
+
+	if isBig(actual) || isBig(threshold) {
+		return compareBig(actual, threshold)
+	}
+
 	actualVal := reflect.ValueOf(actual)
 	thresholdVal := reflect.ValueOf(threshold)
 
@@ -340,6 +799,81 @@ func tryCompare[T cmp.Ordered](actual any, threshold T) (bool, int) {
 	}
 }
 
+func isBig(v any) bool {
+	switch v.(type) {
+	case *big.Int, *big.Float, *big.Rat:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compares two numbers where at least one is a math/big type, by converting
+// both to *big.Rat.
+func compareBig(actual, threshold any) (bool, int) {
+	actualRat, actualInf, ok := toBigRat(actual)
+	if !ok {
+		return false, 0
+	}
+	thresholdRat, thresholdInf, ok := toBigRat(threshold)
+	if !ok {
+		return false, 0
+	}
+	if actualInf != 0 || thresholdInf != 0 {
+		return true, cmp.Compare(actualInf, thresholdInf)
+	}
+	return true, actualRat.Cmp(thresholdRat)
+}
+
+// Converts any number, including math/big types, to a *big.Rat. Infinities
+// can't be represented, so they're reported as `inf` = +1 or -1 instead.
+// Returns ok=false for non-numbers, NaN, and nil pointers.
+func toBigRat(v any) (r *big.Rat, inf int, ok bool) {
+	switch typed := v.(type) {
+	case *big.Int:
+		if typed == nil {
+			return nil, 0, false
+		}
+		return new(big.Rat).SetInt(typed), 0, true
+	case *big.Rat:
+		if typed == nil {
+			return nil, 0, false
+		}
+		return typed, 0, true
+	case *big.Float:
+		if typed == nil {
+			return nil, 0, false
+		}
+		if typed.IsInf() {
+			return nil, typed.Sign(), true
+		}
+		r, _ := typed.Rat(nil)
+		return r, 0, true
+	}
+
+	val := reflect.ValueOf(v)
+	switch {
+	case val.CanInt():
+		return new(big.Rat).SetInt64(val.Int()), 0, true
+	case val.CanUint():
+		return new(big.Rat).SetUint64(val.Uint()), 0, true
+	case val.CanFloat():
+		f := val.Float()
+		if math.IsNaN(f) {
+			return nil, 0, false
+		}
+		if math.IsInf(f, 0) {
+			if f > 0 {
+				return nil, 1, true
+			}
+			return nil, -1, true
+		}
+		return new(big.Rat).SetFloat64(f), 0, true
+	default:
+		return nil, 0, false
+	}
+}
+
 func classify(v any) numClass {
 	val := reflect.ValueOf(v)
 	switch {
@@ -363,6 +897,13 @@ func classify(v any) numClass {
 }
 
 // Conversion helpers
+func asFloat64(x any) (float64, bool) {
+	if classify(x) == numClassNonNumeric {
+		return 0, false
+	}
+	return toFloat64(reflect.ValueOf(x)), true
+}
+
 func toUint64(v reflect.Value) uint64 {
 	switch {
 	case v.CanUint():
@@ -374,6 +915,13 @@ func toUint64(v reflect.Value) uint64 {
 	}
 }
 
+func absUint64(v reflect.Value) uint64 {
+	if v.CanInt() && v.Int() < 0 {
+		return uint64(-v.Int())
+	}
+	return toUint64(v)
+}
+
 func toFloat64(v reflect.Value) float64 {
 	switch {
 	case v.CanFloat():
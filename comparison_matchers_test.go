@@ -1,7 +1,11 @@
 package gotest
 
 import (
+	"math"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 )
 
 type Username string
@@ -203,3 +207,372 @@ func TestLte(t *testing.T) {
 	ExpectThat(t, Username("alice"), Le("bob"))
 	ExpectThat(t, Username("bob"), Le("bob"))
 }
+
+func TestNear(t *testing.T) {
+	a, b := 0.1, 0.2
+	ExpectThat(t, a+b, Not(Eq(0.3)))
+	ExpectThat(t, a+b, Near(0.3, 1e-9))
+	ExpectThat(t, 3.0, Near(3.0, 0))
+	ExpectThat(t, 3.1, Near(3.0, 0.11))
+	ExpectThat(t, 2.9, Near(3.0, 0.11))
+	ExpectThat(t, 3.2, Not(Near(3.0, 0.1)))
+	ExpectThat(t, -3.0, Not(Near(3.0, 0.1)))
+
+	// Other numeric types
+	ExpectThat(t, 10, Near(10.5, 0.5))
+	ExpectThat(t, uint8(10), Near(10.5, 0.5))
+	ExpectThat(t, float32(1.5), Near(1.5, 1e-6))
+	ExpectThat(t, -10, Near(-10.2, 0.5))
+
+	// Edge cases
+	ExpectThat(t, math.NaN(), Not(Near(math.NaN(), 1)))
+	ExpectThat(t, math.Inf(1), Not(Near(0, math.MaxFloat64)))
+	ExpectThat(t, "3.0", Not(Near(3.0, 1)))
+
+	r := testReporter{}
+	ExpectThat(&r, 3.5, Near(3.0, 0.25))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is within 0.25 of 3",
+		"  Got: 3.5 (float64)",
+		"  ...where difference is 0.5",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "3.0", Near(3.0, 0.25))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not a number"))
+}
+
+func TestRelNear(t *testing.T) {
+	ExpectThat(t, 1.001e9, RelNear(1e9, 0.01))
+	ExpectThat(t, 0.99e9, RelNear(1e9, 0.01))
+	ExpectThat(t, 1.1e9, Not(RelNear(1e9, 0.01)))
+	ExpectThat(t, -1.001e9, RelNear(-1e9, 0.01))
+	ExpectThat(t, 1e9, Not(RelNear(-1e9, 0.01)))
+
+	// Other numeric types
+	ExpectThat(t, 99, RelNear(100, 0.01))
+	ExpectThat(t, uint64(101), RelNear(100, 0.01))
+	ExpectThat(t, int8(98), Not(RelNear(100, 0.01)))
+
+	// Edge cases
+	ExpectThat(t, 0, RelNear(0, 0.5))
+	ExpectThat(t, 1e-300, Not(RelNear(0, 0.5)))
+	ExpectThat(t, math.NaN(), Not(RelNear(math.NaN(), 1)))
+	ExpectThat(t, "100", Not(RelNear(100, 1)))
+
+	r := testReporter{}
+	ExpectThat(&r, 120, RelNear(100, 0.1))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is within 10% of 100",
+		"  Got: 120 (int)",
+		"  ...where relative difference is 0.2",
+	}, "\n"))
+}
+
+func TestInRange(t *testing.T) {
+	ExpectThat(t, 5, InRange(1, 10))
+	ExpectThat(t, 1, InRange(1, 10))
+	ExpectThat(t, 10, InRange(1, 10))
+	ExpectThat(t, 0, Not(InRange(1, 10)))
+	ExpectThat(t, 11, Not(InRange(1, 10)))
+
+	// Excluded bounds
+	ExpectThat(t, 1, Not(InRange(1, 10, ExcludeLow())))
+	ExpectThat(t, 10, InRange(1, 10, ExcludeLow()))
+	ExpectThat(t, 10, Not(InRange(1, 10, ExcludeHigh())))
+	ExpectThat(t, 1, InRange(1, 10, ExcludeHigh()))
+	ExpectThat(t, 5, Between(1, 10))
+	ExpectThat(t, 1, Not(Between(1, 10)))
+	ExpectThat(t, 10, Not(Between(1, 10)))
+
+	// Mixed types
+	ExpectThat(t, 2.5, InRange(1, 3))
+	ExpectThat(t, uint8(2), InRange(-1.5, 3.5))
+	ExpectThat(t, -2, Not(InRange(uint(0), uint(5))))
+
+	// Strings
+	ExpectThat(t, "banana", InRange("apple", "cherry"))
+	ExpectThat(t, Username("bob"), InRange("alice", "charlie"))
+	ExpectThat(t, "date", Not(InRange("apple", "cherry")))
+
+	// Incompatible types
+	ExpectThat(t, "5", Not(InRange(1, 10)))
+
+	r := testReporter{}
+	ExpectThat(&r, 0, InRange(1, 10))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is in [1, 10] (int)",
+		"  Got: 0 (int)",
+		"  ...where value is below the lower bound 1",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 10, Between(1, 10))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is in (1, 10) (int)",
+		"  Got: 10 (int)",
+		"  ...where value is equal to the excluded upper bound 10",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 12.5, InRange(1, 10))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is above the upper bound 10"))
+
+	r.Reset()
+	ExpectThat(&r, 1, InRange(1, 10, ExcludeLow()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is equal to the excluded lower bound 1"))
+
+	r.Reset()
+	ExpectThat(&r, "5", InRange(1, 10))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value of type string can't be compared to int"))
+}
+
+type Celsius float64
+
+func TestIsNaN(t *testing.T) {
+	ExpectThat(t, math.NaN(), IsNaN())
+	ExpectThat(t, float32(math.NaN()), IsNaN())
+	ExpectThat(t, Celsius(math.NaN()), IsNaN())
+	ExpectThat(t, 1.5, Not(IsNaN()))
+	ExpectThat(t, math.Inf(1), Not(IsNaN()))
+	ExpectThat(t, 0, Not(IsNaN()))
+	ExpectThat(t, struct{ F float64 }{math.NaN()}, Field("F", IsNaN()))
+
+	r := testReporter{}
+	ExpectThat(&r, 1.5, IsNaN())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is NaN",
+		"  Got: 1.5 (float64)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 1, IsNaN())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a float"))
+}
+
+func TestIsInf(t *testing.T) {
+	ExpectThat(t, math.Inf(1), IsInf(1))
+	ExpectThat(t, math.Inf(1), IsInf(0))
+	ExpectThat(t, math.Inf(1), Not(IsInf(-1)))
+	ExpectThat(t, math.Inf(-1), IsInf(-1))
+	ExpectThat(t, math.Inf(-1), IsInf(0))
+	ExpectThat(t, math.Inf(-1), Not(IsInf(1)))
+	ExpectThat(t, float32(math.Inf(1)), IsInf(1))
+	ExpectThat(t, Celsius(math.Inf(-1)), IsInf(-1))
+	ExpectThat(t, math.MaxFloat64, Not(IsInf(0)))
+	ExpectThat(t, math.NaN(), Not(IsInf(0)))
+	ExpectThat(t, math.MaxInt64, Not(IsInf(0)))
+
+	ExpectEq(t, IsInf(1).String(), "is +Inf")
+	ExpectEq(t, IsInf(-1).String(), "is -Inf")
+	ExpectEq(t, IsInf(0).String(), "is infinite")
+}
+
+func TestSign(t *testing.T) {
+	ExpectThat(t, 5, Positive())
+	ExpectThat(t, uint8(1), Positive())
+	ExpectThat(t, uint64(math.MaxUint64), Positive())
+	ExpectThat(t, 0.5, Positive())
+	ExpectThat(t, math.Inf(1), Positive())
+	ExpectThat(t, 0, Not(Positive()))
+	ExpectThat(t, 0.0, Not(Positive()))
+	ExpectThat(t, -1, Not(Positive()))
+	ExpectThat(t, math.NaN(), Not(Positive()))
+	ExpectThat(t, "5", Not(Positive()))
+
+	ExpectThat(t, -5, Negative())
+	ExpectThat(t, int8(-1), Negative())
+	ExpectThat(t, -0.5, Negative())
+	ExpectThat(t, math.Inf(-1), Negative())
+	ExpectThat(t, 0, Not(Negative()))
+	ExpectThat(t, math.Copysign(0, -1), Not(Negative()))
+	ExpectThat(t, uint(0), Not(Negative()))
+	ExpectThat(t, math.NaN(), Not(Negative()))
+
+	ExpectThat(t, -5, NonZero())
+	ExpectThat(t, uint(3), NonZero())
+	ExpectThat(t, 1e-300, NonZero())
+	ExpectThat(t, 0, Not(NonZero()))
+	ExpectThat(t, 0.0, Not(NonZero()))
+	ExpectThat(t, uint16(0), Not(NonZero()))
+	ExpectThat(t, "", Not(NonZero()))
+
+	r := testReporter{}
+	ExpectThat(&r, -3, Positive())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a positive number",
+		"  Got: -3 (int)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "3", Negative())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a negative number",
+		"  Got: 3 (string)",
+		"  ...where value is of type string, not a number",
+	}, "\n"))
+}
+
+func TestDivisibleBy(t *testing.T) {
+	ExpectThat(t, 12, DivisibleBy(4))
+	ExpectThat(t, 0, DivisibleBy(7))
+	ExpectThat(t, -9, DivisibleBy(3))
+	ExpectThat(t, 9, DivisibleBy(-3))
+	ExpectThat(t, 10, Not(DivisibleBy(3)))
+	ExpectThat(t, -10, Not(DivisibleBy(3)))
+
+	// Mixed types
+	ExpectThat(t, uint64(4096), DivisibleBy(512))
+	ExpectThat(t, int8(-128), DivisibleBy(uint8(64)))
+	ExpectThat(t, uint64(math.MaxUint64), DivisibleBy(uint64(math.MaxUint64)))
+	ExpectThat(t, int64(math.MinInt64), DivisibleBy(int64(1<<62)))
+	ExpectThat(t, uint64(math.MaxUint64), Not(DivisibleBy(2)))
+
+	// Non-integers
+	ExpectThat(t, 12.0, Not(DivisibleBy(4)))
+	ExpectThat(t, "12", Not(DivisibleBy(4)))
+
+	ExpectFatal(t, Eq("DivisibleBy: divisor must be non-zero"), func() {
+		DivisibleBy(0)
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, 10, DivisibleBy(4))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is divisible by 4",
+		"  Got: 10 (int)",
+		"  ...where remainder is 2",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 10.0, DivisibleBy(4))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type float64, not an integer"))
+}
+
+func TestBigNumbers(t *testing.T) {
+	huge, _ := new(big.Int).SetString("100000000000000000000000000000", 10)
+	half := big.NewRat(1, 2)
+
+	// Big values against native thresholds
+	ExpectThat(t, huge, Gt(math.MaxInt64))
+	ExpectThat(t, huge, Gt(uint64(math.MaxUint64)))
+	ExpectThat(t, huge, Lt(1e30))
+	ExpectThat(t, big.NewInt(5), Ge(5))
+	ExpectThat(t, big.NewInt(5), Le(5.0))
+	ExpectThat(t, big.NewInt(-5), Lt(0))
+	ExpectThat(t, half, Gt(0.49))
+	ExpectThat(t, half, Not(Gt(0.5)))
+	ExpectThat(t, big.NewFloat(2.5), Lt(3))
+
+	// Native values against big thresholds
+	ExpectThat(t, math.MaxInt64, Lt(huge))
+	ExpectThat(t, 0.75, Gt(half))
+	ExpectThat(t, 1, Not(Lt(half)))
+	ExpectThat(t, -1, InRange(big.NewInt(-2), big.NewInt(0)))
+
+	// Big values against each other
+	ExpectThat(t, huge, Gt(big.NewFloat(1e29)))
+	ExpectThat(t, half, Lt(big.NewInt(1)))
+	ExpectThat(t, big.NewFloat(0.5), Ge(half))
+
+	// Infinities
+	ExpectThat(t, huge, Lt(math.Inf(1)))
+	ExpectThat(t, math.Inf(-1), Lt(huge))
+	ExpectThat(t, new(big.Float).SetInf(false), Gt(huge))
+	ExpectThat(t, new(big.Float).SetInf(false), Ge(math.Inf(1)))
+
+	// Incomparable values
+	ExpectThat(t, math.NaN(), Not(Lt(huge)))
+	ExpectThat(t, "5", Not(Lt(huge)))
+	ExpectThat(t, (*big.Int)(nil), Not(Lt(5)))
+
+	r := testReporter{}
+	ExpectThat(&r, big.NewInt(3), Gt(5))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is greater than 5 (int)",
+		"  Got: 3 (*big.Int)",
+	}, "\n"))
+}
+
+func TestTimes(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	ExpectThat(t, t1, Gt(t0))
+	ExpectThat(t, t0, Not(Gt(t0)))
+	ExpectThat(t, t0, Lt(t1))
+	ExpectThat(t, t1, Not(Lt(t0)))
+	ExpectThat(t, t0, Ge(t0))
+	ExpectThat(t, t1, Ge(t0))
+	ExpectThat(t, t0, Le(t0))
+	ExpectThat(t, t1, Not(Le(t0)))
+	ExpectThat(t, t0.Add(30*time.Minute), InRange(t0, t1))
+
+	// Same instant in a different location
+	ExpectThat(t, t0.In(time.FixedZone("EST", -5*60*60)), Ge(t0))
+	ExpectThat(t, t0.In(time.FixedZone("EST", -5*60*60)), Le(t0))
+
+	// Durations
+	ExpectThat(t, 2*time.Second, Gt(time.Second))
+	ExpectThat(t, 500*time.Millisecond, Lt(time.Second))
+
+	// Incompatible types
+	ExpectThat(t, t0.Unix(), Not(Lt(t1)))
+	ExpectThat(t, t0, Not(Lt(5)))
+
+	r := testReporter{}
+	ExpectThat(&r, t0, Gt(t1))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is after 2024-01-02T16:04:05Z",
+		"  Got: " + formatGot(t0, nil),
+	}, "\n"))
+
+	ExpectEq(t, Lt(t0).String(), "is before 2024-01-02T15:04:05Z")
+	ExpectEq(t, Ge(t0).String(), "is at or after 2024-01-02T15:04:05Z")
+	ExpectEq(t, Le(t0).String(), "is at or before 2024-01-02T15:04:05Z")
+	ExpectEq(t, Gt(time.Second).String(), "is greater than 1s (time.Duration)")
+}
+
+func TestWithin(t *testing.T) {
+	// Half-open by default
+	ExpectThat(t, 0, Within(0, 10))
+	ExpectThat(t, 9.99, Within(0, 10))
+	ExpectThat(t, 10, Not(Within(0, 10)))
+	ExpectThat(t, -1, Not(Within(0, 10)))
+
+	// Explicit bounds
+	ExpectThat(t, 10, Within(0, 10, IncludeHigh()))
+	ExpectThat(t, 0, Not(Within(0, 10, ExcludeLow())))
+	ExpectThat(t, 0, Within(0, 10, IncludeLow(), ExcludeHigh()))
+	ExpectThat(t, 0.5, Within(0, 1, ExcludeLow(), ExcludeHigh()))
+	ExpectThat(t, 1, InRange(0, 1, ExcludeHigh(), IncludeHigh()))
+
+	// Descriptions use interval notation
+	ExpectEq(t, Within(0, 10).String(), "is in [0, 10) (int)")
+	ExpectEq(t, Within(0, 10, ExcludeLow(), IncludeHigh()).String(), "is in (0, 10] (int)")
+	ExpectEq(t, InRange(0.5, 1.5).String(), "is in [0.5, 1.5] (float64)")
+	ExpectEq(t, Between("a", "c").String(), "is in (a, c) (string)")
+
+	t0 := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	ExpectEq(t, Within(t0, t0.Add(time.Hour)).String(),
+		"is in [2024-01-02T15:04:05Z, 2024-01-02T16:04:05Z)")
+
+	r := testReporter{}
+	ExpectThat(&r, 10, Within(0, 10))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is in [0, 10) (int)",
+		"  Got: 10 (int)",
+		"  ...where value is equal to the excluded upper bound 10",
+	}, "\n"))
+}
@@ -0,0 +1,164 @@
+package gotest
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Changes how ExpectThat(), AssertThat(), and the helpers built on them
+// behave in the test `t`, until it finishes. Calling this again adds to the
+// test's configuration. Subtests aren't affected; configure them separately.
+//
+// Example:
+//
+//	func TestReport(t *testing.T) {
+//		Configure(t, FailFast(), UseDiffStyle(PathDiff), UseOutputLimits(OutputLimits{MaxLines: 50}))
+//		...
+//	}
+func Configure(t interface {
+	gomock.TestHelper
+	Cleanup(func())
+}, opts ...ConfigOption) {
+	configsMu.Lock()
+	defer configsMu.Unlock()
+	c, ok := configs[t]
+	if !ok {
+		c = &testConfig{}
+		configs[t] = c
+		t.Cleanup(func() {
+			configsMu.Lock()
+			defer configsMu.Unlock()
+			delete(configs, t)
+		})
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// Configures a test. See Configure().
+type ConfigOption func(*testConfig)
+
+type testConfig struct {
+	failFast  bool
+	diffStyle DiffStyle
+	limits    *OutputLimits
+	color     bool
+}
+
+var (
+	configsMu sync.Mutex
+	configs   = map[gomock.TestHelper]*testConfig{}
+)
+
+// Returns the configuration of the test `t`, which is the zero value if it
+// hasn't been configured.
+func configFor(t gomock.TestHelper) testConfig {
+	switch r := t.(type) {
+	case softReporter:
+		c := configFor(r.t)
+		c.failFast = false
+		return c
+	case *goroutineReporter:
+		return configFor(r.t)
+	}
+	configsMu.Lock()
+	defer configsMu.Unlock()
+	// Tests that couldn't have been configured aren't looked up, since fakes
+	// of testing.T might not be usable as map keys.
+	if len(configs) == 0 || !reflect.TypeOf(t).Comparable() {
+		return testConfig{}
+	}
+	if c, ok := configs[t]; ok {
+		return *c
+	}
+	return testConfig{}
+}
+
+// Makes failed expectations terminate the test immediately, like assertions,
+// rather than letting it carry on to collect further failures.
+func FailFast() ConfigOption {
+	return func(c *testConfig) {
+		c.failFast = true
+	}
+}
+
+// Overrides DefaultDiffStyle for Eq() matchers that are passed directly to
+// assertions, unless they set their own with WithDiffStyle().
+func UseDiffStyle(style DiffStyle) ConfigOption {
+	return func(c *testConfig) {
+		c.diffStyle = style
+	}
+}
+
+// Overrides DefaultOutputLimits, except for matchers that set their own with
+// WithOutputLimits().
+func UseOutputLimits(limits OutputLimits) ConfigOption {
+	return func(c *testConfig) {
+		c.limits = &limits
+	}
+}
+
+// Colors the lines of diffs in failure output with ANSI escape codes: red for
+// removed lines, and green for added ones.
+func UseColor(enabled bool) ConfigOption {
+	return func(c *testConfig) {
+		c.color = enabled
+	}
+}
+
+// Applies the configuration to a matcher passed to an assertion.
+func (c testConfig) configure(m Matcher) Matcher {
+	switch inner := m.(type) {
+	case eqMatcher:
+		if inner.diffStyle == 0 {
+			inner.diffStyle = c.diffStyle
+		}
+		m = inner
+	case messageMatcher:
+		inner.inner = c.configure(inner.inner)
+		m = inner
+	}
+	if _, ok := ownOutputLimits(m); !ok && c.limits != nil {
+		m = WithOutputLimits(m, *c.limits)
+	}
+	return m
+}
+
+// Reports a failure with the explanation `e`, as configured.
+func (c testConfig) fail(t gomock.TestHelper, fatal bool, e string) {
+	t.Helper()
+	if c.color {
+		e = colorDiff(e)
+	}
+	if fatal || c.failFast {
+		t.Fatalf("%s", e)
+	} else {
+		t.Errorf("%s", e)
+	}
+}
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// Colors the lines of `s` that look like removed or added lines of a diff.
+func colorDiff(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		switch {
+		case strings.HasPrefix(trimmed, "---") || strings.HasPrefix(trimmed, "+++"):
+		case strings.HasPrefix(trimmed, "-"):
+			lines[i] = ansiRed + line + ansiReset
+		case strings.HasPrefix(trimmed, "+"):
+			lines[i] = ansiGreen + line + ansiReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,138 @@
+package gotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// A testReporter that can also register cleanups, like testing.T.
+type cleanupReporter struct {
+	testReporter
+	cleanups []func()
+}
+
+func (r *cleanupReporter) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *cleanupReporter) finish() {
+	for _, f := range r.cleanups {
+		f()
+	}
+	r.cleanups = nil
+}
+
+func TestConfigure_FailFast(t *testing.T) {
+	r := &cleanupReporter{}
+	Configure(r, FailFast())
+	ExpectThat(r, 2, Gt(3))
+	ExpectEq(t, r.nonFatals, nil)
+	ExpectThat(t, r.fatals, ElementsAre(StartsWith("Expectation failed:\n")))
+
+	// Other tests, and this one once it's finished, aren't affected.
+	other := &cleanupReporter{}
+	ExpectThat(other, 2, Gt(3))
+	ExpectEq(t, len(other.nonFatals), 1)
+
+	r.finish()
+	r.Reset()
+	ExpectThat(r, 2, Gt(3))
+	ExpectEq(t, len(r.nonFatals), 1)
+	ExpectEq(t, r.fatals, nil)
+}
+
+func TestConfigure_DiffStyleAndLimits(t *testing.T) {
+	type pair struct{ A, B int }
+	r := &cleanupReporter{}
+	defer r.finish()
+	Configure(r, UseDiffStyle(PathDiff))
+	Configure(r, UseOutputLimits(OutputLimits{MaxLines: 1}))
+
+	ExpectEq(r, pair{1, 2}, pair{1, 3})
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to {A:1 B:3} (gotest.pair)",
+		"  Got: {1 2} (gotest.pair)",
+		"  ...where doesn't match at (want != got):",
+		"... 1 more lines truncated",
+	}, "\n"))
+
+	// Matchers' own settings take precedence.
+	r.Reset()
+	ExpectThatf(r, pair{1, 2}, WithOutputLimits(Eq(pair{1, 3}, WithDiffStyle(FullDiff)), OutputLimits{}), "message")
+	ExpectThat(t, r.nonFatals[0], AllOf(
+		HasSubstr("  ...where doesn't match (-want +got):\n"),
+		Not(HasSubstr("truncated")),
+		HasSubstr("  Message: message"),
+	))
+
+	r.Reset()
+	ExpectEqf(r, pair{1, 2}, pair{1, 3}, "message")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("  ...where doesn't match at (want != got):\n... 1 more lines truncated"))
+}
+
+func TestConfigure_Color(t *testing.T) {
+	r := &cleanupReporter{}
+	defer r.finish()
+	Configure(r, UseColor(true))
+	AssertThat(r, "a\nb", LinesEq("a\nc"))
+	ExpectEq(t, r.fatals[0], strings.Join([]string{
+		"Assertion failed:",
+		"  Wanted: is equal to the 2 lines starting with 'a'",
+		"  Got: a",
+		"b (string)",
+		"  ...where lines differ (-want +got):",
+		"       1 | a",
+		"\x1b[31m  -    2 | c\x1b[0m",
+		"\x1b[32m  +    2 | b\x1b[0m",
+	}, "\n"))
+
+	r.Reset()
+	Configure(r, UseColor(false))
+	AssertThat(r, "a\nb", LinesEq("a\nc"))
+	ExpectThat(t, r.fatals[0], Not(HasSubstr("\x1b[")))
+}
+
+func TestConfigure_Helpers(t *testing.T) {
+	r := &cleanupReporter{}
+	defer r.finish()
+	Configure(r, FailFast())
+	m := &mockSender{gomock.NewController(t)}
+	call := m.expectSend(gomock.Any(), gomock.Any()).Return(nil)
+	ExpectCallArgs(r, call, "alice", "hi")
+
+	WithContext(context.Background(), r).ExpectThat(2, Gt(3))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	WithContext(ctx, r).Eventually(func() any { return 2 }, Gt(3), time.Millisecond)
+	ExpectNoPanic(r, func() { panic("boom") })
+	ExpectFatal(r, Any(), func() {})
+	m.Send("bob", "hi")
+	ExpectThat(t, r.fatals, ElementsAre(
+		StartsWith("Expectation failed:"),
+		StartsWith("Eventually aborted (context deadline exceeded)"),
+		StartsWith("Expected no panic"),
+		"Expected fatal error, but none occurred...",
+	))
+	// Mocked methods may be called on other goroutines, so their checks
+	// aren't fatal.
+	ExpectThat(t, r.nonFatals, ElementsAre(StartsWith("Argument 0 of call failed:")))
+}
+
+func TestConfigure_FailFastExpectCalled(t *testing.T) {
+	r := &cleanupReporter{}
+	Configure(r, FailFast())
+	m := &mockSender{gomock.NewController(t)}
+	ExpectCalled(r, m.expectSend(gomock.Any(), gomock.Any()), 2, "alice", Any()).Return(nil)
+	m.Send("bob", "hi")
+	r.finish()
+	ExpectEq(t, r.fatals, nil)
+	ExpectThat(t, r.nonFatals, ElementsAre(
+		StartsWith("Argument 0 of call 1 failed:"),
+		HasSubstr("  Wanted: number of calls is equal to 2 (int)\n  Got: 1 (int)"),
+	))
+}
@@ -1,10 +1,13 @@
 package gotest
 
 import (
+	"cmp"
 	"fmt"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Matches values whose length fulfills `innerMatcher`. Length is defined by
@@ -61,6 +64,12 @@ func (e emptyMatcher) ExplainFailure(x any) (string, bool) {
 // Matches slices or arrays containing all of the provided elements, in any
 // order, potentially with additional elements as well.
 //
+// Maps are matched by their values (or by their key-value pairs, if
+// MapEntries() is passed among the elements), and buffered channels by the
+// values currently in them, which are left in place. Channels that are
+// receive-only, unbuffered, or closed with values in them aren't iterable,
+// since inspecting their values would consume them.
+//
 // If any of `elements` could match multiple of the elements of the value, then
 // there must be a mapping such that a different element of the value fulfills
 // each of `elements`. (As a corollary, matching values must have length at
@@ -82,11 +91,7 @@ func (e emptyMatcher) ExplainFailure(x any) (string, bool) {
 //	// no match, because 'bb' is the only element that fulfills either matcher
 //	ExpectThat(t, []string{"a", "bb", "ccc", "dd"}, Not(Contains("bb", StartsWith("b"))))
 func Contains(elements ...any) Matcher {
-	matchers := make([]Matcher, len(elements))
-	for i, el := range elements {
-		matchers[i] = AsMatcher(el)
-	}
-	return unorderedMatcher{matchers, false}
+	return newUnorderedMatcher(elements, unorderedMatcher{})
 }
 
 // Tests that a slice or array contains exactly the provided elements, in
@@ -132,11 +137,126 @@ func ElementsAre(elements ...any) Matcher {
 //	ExpectThat(t, []string{"a", "b", "ccc"}, Not(ElementsAreUnordered(Any(), Any())))
 //	ExpectThat(t, []string{"a", "b", "ccc"}, Not(ElementsAreUnordered("a", "ccc", Len(Gt(1)))))
 func ElementsAreUnordered(elements ...any) Matcher {
-	matchers := make([]Matcher, len(elements))
+	return newUnorderedMatcher(elements, unorderedMatcher{matchAll: true})
+}
+
+// Same as ElementsAre(), but takes the expected elements as a slice, which
+// can hold exact values, matchers, or a mix (as []any).
+//
+// Examples:
+//
+//	want := loadFixture(t)  // []string
+//	ExpectThat(t, got, ElementsAreSlice(want))
+//	ExpectThat(t, got, ElementsAreSlice([]Matcher{StartsWith("a"), Any()}))
+func ElementsAreSlice[T any](elements []T) Matcher {
+	return ElementsAre(toAnySlice(elements)...)
+}
+
+// Same as ElementsAreUnordered(), but takes the expected elements as a slice,
+// which can hold exact values, matchers, or a mix (as []any).
+//
+// Examples:
+//
+//	ExpectThat(t, got, UnorderedElementsAreSlice(want))
+//	ExpectThat(t, got, UnorderedElementsAreSlice([]Matcher{Gt(1), Lt(0)}))
+func UnorderedElementsAreSlice[T any](elements []T) Matcher {
+	return ElementsAreUnordered(toAnySlice(elements)...)
+}
+
+func toAnySlice[T any](elements []T) []any {
+	out := make([]any, len(elements))
 	for i, el := range elements {
-		matchers[i] = AsMatcher(el)
+		out[i] = el
+	}
+	return out
+}
+
+// Tests that every element of a slice or array matches one of the provided
+// elements, with a different one for each - the inverse of Contains(). The
+// value may be empty, and not all of `elements` need to be used.
+//
+// Each element can be either an exact value (tested by equality) or a
+// matcher.
+//
+// Examples:
+//
+//	ExpectThat(t, granted, SubsetOf("read", "write", "admin"))
+//	ExpectThat(t, []int{1, 5}, SubsetOf(1, Gt(3), Lt(0)))
+//	ExpectThat(t, []string{}, SubsetOf("a"))
+//	// no match, because "a" can only be paired with one of the elements
+//	ExpectThat(t, []string{"a", "a"}, Not(SubsetOf("a", "b")))
+func SubsetOf(elements ...any) Matcher {
+	return newUnorderedMatcher(elements, unorderedMatcher{subset: true})
+}
+
+// Tests that a slice or array contains all of the provided elements, in any
+// order, potentially with additional elements as well. By default this is the
+// same as Contains(): each of `elements` must be matched by a different
+// element of the value, so duplicates in `elements` require duplicates in the
+// value (multiset semantics).
+//
+// Passing IgnoreMultiplicity() among the elements relaxes this, so that each
+// of `elements` only needs to match some element of the value (set
+// semantics).
+//
+// Examples:
+//
+//	ExpectThat(t, []string{"a", "b", "c"}, SupersetOf("c", "a"))
+//	ExpectThat(t, []string{"a", "b"}, Not(SupersetOf("a", "a")))
+//	ExpectThat(t, []string{"a", "b"}, SupersetOf("a", "a", IgnoreMultiplicity()))
+//	ExpectThat(t, []int{5}, SupersetOf(Gt(1), Gt(2), IgnoreMultiplicity()))
+func SupersetOf(elements ...any) Matcher {
+	return newUnorderedMatcher(elements, unorderedMatcher{})
+}
+
+// Configures Contains(), ElementsAreUnordered(), SubsetOf() and SupersetOf().
+// Options are passed along with the elements.
+type SetOption func(*unorderedMatcher)
+
+// Allows several of the expected elements to be matched by the same element
+// of the value, and vice versa, so that the elements are compared as sets:
+// duplicates among the expected elements don't require duplicates in the
+// value, and for SubsetOf() and ElementsAreUnordered(), duplicates in the
+// value don't require duplicates among the expected elements.
+//
+// Examples:
+//
+//	ExpectThat(t, []string{"a", "a"}, SubsetOf("a", "b", IgnoreMultiplicity()))
+//	ExpectThat(t, []string{"a", "b", "a"}, ElementsAreUnordered("b", "a", IgnoreMultiplicity()))
+func IgnoreMultiplicity() SetOption {
+	return func(m *unorderedMatcher) { m.reuse = true }
+}
+
+// Matches the elements against the key-value pairs of maps, rather than just
+// their values. Elements should be created with KeyVal().
+//
+// Examples:
+//
+//	m := map[string]int{"a": 1, "bb": 2}
+//	ExpectThat(t, m, Contains(KeyVal(Len(2), Gt(1)), MapEntries()))
+//	ExpectThat(t, m, ElementsAreUnordered(KeyVal("a", 1), KeyVal("bb", 2), MapEntries()))
+func MapEntries() SetOption {
+	return func(m *unorderedMatcher) { m.entries = true }
+}
+
+// Fills in the elements of `m`, applying any SetOptions among them.
+func newUnorderedMatcher(elements []any, m unorderedMatcher) unorderedMatcher {
+	for _, el := range elements {
+		if opt, ok := el.(SetOption); ok {
+			opt(&m)
+		}
+	}
+	for _, el := range elements {
+		if _, ok := el.(SetOption); ok {
+			continue
+		}
+		if kv, ok := el.(KeyValT); ok && m.entries {
+			m.elements = append(m.elements, &keyValMatcher{K: AsMatcher(kv.K), V: AsMatcher(kv.V)})
+		} else {
+			m.elements = append(m.elements, AsMatcher(el))
+		}
 	}
-	return unorderedMatcher{matchers, true}
+	return m
 }
 
 // Tests that a map contains exactly the elements of `mapValues`, and no
@@ -198,6 +318,330 @@ func MapContains[K comparable, V any](mapValues map[K]V) Matcher {
 	return mapMatcher[K]{matchers, false}
 }
 
+// Matches maps whose keys, as a slice, fulfill `innerMatcher`. Typically
+// `innerMatcher` is a container matcher like ElementsAreUnordered() or
+// Contains().
+//
+// Keys of ordered types (numbers and strings) are sorted; other keys are in
+// unspecified order.
+//
+// Examples:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	ExpectThat(t, m, Keys(ElementsAreUnordered("a", "b")))
+//	ExpectThat(t, m, Keys([]string{"a", "b"}))
+//	ExpectThat(t, m, Keys(Contains(StartsWith("a"))))
+func Keys(innerMatcher any) Matcher {
+	return mapProjectionMatcher{AsMatcher(innerMatcher), false}
+}
+
+// Matches maps whose values, as a slice, fulfill `innerMatcher`. Typically
+// `innerMatcher` is a container matcher like ElementsAreUnordered() or
+// Contains().
+//
+// Values are ordered by their keys if the keys are of an ordered type
+// (numbers and strings); otherwise they're in unspecified order.
+//
+// Examples:
+//
+//	m := map[string]int{"a": 1, "b": 2}
+//	ExpectThat(t, m, Values(ElementsAreUnordered(2, 1)))
+//	ExpectThat(t, m, Values([]int{1, 2}))
+//	ExpectThat(t, m, Values(Contains(Gt(1))))
+func Values(innerMatcher any) Matcher {
+	return mapProjectionMatcher{AsMatcher(innerMatcher), true}
+}
+
+// Same as Keys(), for readers who'd rather the name say it works on maps.
+//
+// Examples:
+//
+//	ExpectThat(t, m, MapKeys(Each(StartsWith("user:"))))
+//	ExpectThat(t, m, MapKeys(Contains("user:admin")))
+func MapKeys(innerMatcher any) Matcher {
+	return Keys(innerMatcher)
+}
+
+// Same as Values(), for readers who'd rather the name say it works on maps.
+//
+// Examples:
+//
+//	ExpectThat(t, m, MapValues(Each(Gt(0))))
+//	ExpectThat(t, m, MapValues(HasNoDuplicates()))
+func MapValues(innerMatcher any) Matcher {
+	return Values(innerMatcher)
+}
+
+type mapProjectionMatcher struct {
+	innerMatcher Matcher
+
+	// If true, project the map to its values. Otherwise, to its keys.
+	values bool
+}
+
+func (m mapProjectionMatcher) project(x any) (any, bool) {
+	r := reflect.ValueOf(x)
+	if r.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	keys := sortedMapKeys(r)
+	var out reflect.Value
+	if m.values {
+		out = reflect.MakeSlice(reflect.SliceOf(r.Type().Elem()), 0, len(keys))
+		for _, k := range keys {
+			out = reflect.Append(out, r.MapIndex(k))
+		}
+	} else {
+		out = reflect.MakeSlice(reflect.SliceOf(r.Type().Key()), 0, len(keys))
+		out = reflect.Append(out, keys...)
+	}
+	return out.Interface(), true
+}
+
+func (m mapProjectionMatcher) Matches(x any) bool {
+	if projected, ok := m.project(x); ok {
+		return m.innerMatcher.Matches(projected)
+	}
+	return false
+}
+
+func (m mapProjectionMatcher) what() string {
+	if m.values {
+		return "values"
+	}
+	return "keys"
+}
+
+func (m mapProjectionMatcher) String() string {
+	return fmt.Sprintf("has %s which %s", m.what(), m.innerMatcher.String())
+}
+
+func (m mapProjectionMatcher) ExplainFailure(x any) (string, bool) {
+	projected, ok := m.project(x)
+	if !ok {
+		return fmt.Sprintf("type %T isn't a map", x), true
+	}
+	explanation := fmt.Sprintf("%s are %s", m.what(), formatGot(projected, m.innerMatcher))
+	explanation = appendExplanation(explanation, m.innerMatcher, projected)
+	return explanation, true
+}
+
+// Matches maps with a key that fulfills `key`, regardless of its value.
+//
+// Examples:
+//
+//	m := map[string]int{"alpha": 1, "beta": 2}
+//	ExpectThat(t, m, HasKey("alpha"))
+//	ExpectThat(t, m, HasKey(StartsWith("b")))
+//	ExpectThat(t, m, Not(HasKey("gamma")))
+func HasKey(key any) Matcher {
+	return hasKeysMatcher{[]Matcher{AsMatcher(key)}}
+}
+
+// Matches maps which, for each of `keys`, have a key that fulfills it,
+// regardless of the values. The map may have other keys as well.
+//
+// If some of `keys` are matchers, the same key of the map may satisfy more
+// than one of them.
+//
+// Examples:
+//
+//	m := map[string]int{"alpha": 1, "beta": 2, "gamma": 3}
+//	ExpectThat(t, m, HasKeys("alpha", "beta"))
+//	ExpectThat(t, m, HasKeys("gamma", HasSubstr("et")))
+//	ExpectThat(t, m, Not(HasKeys("alpha", "delta")))
+func HasKeys(keys ...any) Matcher {
+	matchers := make([]Matcher, len(keys))
+	for i, k := range keys {
+		matchers[i] = AsMatcher(k)
+	}
+	return hasKeysMatcher{matchers}
+}
+
+type hasKeysMatcher struct {
+	keys []Matcher
+}
+
+// Returns the matchers in `m.keys` that none of the map's keys fulfill, and
+// false if `x` isn't a map.
+func (m hasKeysMatcher) missing(x any) ([]Matcher, bool) {
+	r := reflect.ValueOf(x)
+	if r.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	var missing []Matcher
+	for _, matcher := range m.keys {
+		found := false
+		for _, k := range r.MapKeys() {
+			if matcher.Matches(k.Interface()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, matcher)
+		}
+	}
+	return missing, true
+}
+
+func (m hasKeysMatcher) Matches(x any) bool {
+	missing, ok := m.missing(x)
+	return ok && len(missing) == 0
+}
+
+func (m hasKeysMatcher) String() string {
+	if len(m.keys) == 1 {
+		return fmt.Sprintf("has a key which %s", m.keys[0].String())
+	}
+	keyStrings := make([]string, len(m.keys))
+	for i, k := range m.keys {
+		keyStrings[i] = k.String()
+	}
+	return fmt.Sprintf("has keys matching [%s]", strings.Join(keyStrings, "; "))
+}
+
+func (m hasKeysMatcher) ExplainFailure(x any) (string, bool) {
+	missing, ok := m.missing(x)
+	if !ok {
+		return fmt.Sprintf("type %T isn't a map", x), true
+	}
+	if len(missing) == 0 {
+		return "", false
+	}
+
+	problems := make([]string, len(missing))
+	for i, matcher := range missing {
+		problems[i] = fmt.Sprintf("no key %s", matcher.String())
+	}
+	keys := sortedMapKeys(reflect.ValueOf(x))
+	keyValues := make([]any, len(keys))
+	for i, k := range keys {
+		keyValues[i] = k.Interface()
+	}
+	return fmt.Sprintf("%s (keys are %v)", strings.Join(problems, "; "), keyValues), true
+}
+
+// Matches maps with at least one value that fulfills `value`, regardless of
+// its key.
+//
+// Examples:
+//
+//	m := map[string]int{"alpha": 1, "beta": 20}
+//	ExpectThat(t, m, HasValue(20))
+//	ExpectThat(t, m, HasValue(Gt(10)))
+//	ExpectThat(t, m, Not(HasValue(Lt(0))))
+func HasValue(value any) Matcher {
+	return hasValuesMatcher{[]Matcher{AsMatcher(value)}}
+}
+
+// Matches maps which, for each of `values`, have a value that fulfills it,
+// regardless of the keys. The map may have other values as well.
+//
+// If some of `values` are matchers, the same value of the map may satisfy
+// more than one of them.
+//
+// Examples:
+//
+//	m := map[string]int{"alpha": 1, "beta": 20, "gamma": 300}
+//	ExpectThat(t, m, ContainsValues(1, 20))
+//	ExpectThat(t, m, ContainsValues(Gt(100), Lt(10)))
+//	ExpectThat(t, m, Not(ContainsValues(1, 2)))
+func ContainsValues(values ...any) Matcher {
+	matchers := make([]Matcher, len(values))
+	for i, v := range values {
+		matchers[i] = AsMatcher(v)
+	}
+	return hasValuesMatcher{matchers}
+}
+
+// The most non-matching values listed for each unsatisfied matcher when
+// explaining a failure.
+const maxListedValues = 3
+
+type hasValuesMatcher struct {
+	values []Matcher
+}
+
+// Returns the matchers in `m.values` that none of the map's values fulfill,
+// along with the map's values, or false if `x` isn't a map.
+func (m hasValuesMatcher) missing(x any) ([]Matcher, []element, bool) {
+	if reflect.ValueOf(x).Kind() != reflect.Map {
+		return nil, nil, false
+	}
+	elements, _ := collectionElements(x)
+
+	var missing []Matcher
+	for _, matcher := range m.values {
+		if !slices.ContainsFunc(elements, func(el element) bool { return matcher.Matches(el.value) }) {
+			missing = append(missing, matcher)
+		}
+	}
+	return missing, elements, true
+}
+
+func (m hasValuesMatcher) Matches(x any) bool {
+	missing, _, ok := m.missing(x)
+	return ok && len(missing) == 0
+}
+
+func (m hasValuesMatcher) String() string {
+	if len(m.values) == 1 {
+		return fmt.Sprintf("has a value which %s", m.values[0].String())
+	}
+	valueStrings := make([]string, len(m.values))
+	for i, v := range m.values {
+		valueStrings[i] = v.String()
+	}
+	return fmt.Sprintf("has values matching [%s]", strings.Join(valueStrings, "; "))
+}
+
+func (m hasValuesMatcher) ExplainFailure(x any) (string, bool) {
+	missing, elements, ok := m.missing(x)
+	if !ok {
+		return fmt.Sprintf("type %T isn't a map", x), true
+	}
+	if len(missing) == 0 {
+		return "", false
+	}
+	if len(elements) == 0 {
+		return "map is empty", true
+	}
+
+	problems := make([]string, len(missing))
+	for i, matcher := range missing {
+		var candidates []string
+		for _, el := range elements[:min(len(elements), maxListedValues)] {
+			candidate := fmt.Sprintf("%s is %s", el.label(), formatGot(el.value, matcher))
+			candidate = appendExplanation(candidate, matcher, el.value)
+			candidates = append(candidates, candidate)
+		}
+		if len(elements) > maxListedValues {
+			candidates = append(candidates, fmt.Sprintf("and %d more", len(elements)-maxListedValues))
+		}
+		problems[i] = fmt.Sprintf("no value %s (%s)", matcher.String(), strings.Join(candidates, "; "))
+	}
+	return strings.Join(problems, "; "), true
+}
+
+// Returns the keys of the map `r`, sorted if they're of an ordered type.
+func sortedMapKeys(r reflect.Value) []reflect.Value {
+	keys := r.MapKeys()
+	switch r.Type().Key().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) })
+	case reflect.Float32, reflect.Float64:
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) })
+	case reflect.String:
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) })
+	}
+	return keys
+}
+
 type KeyValT struct {
 	K any
 	V any
@@ -415,45 +859,85 @@ type unorderedMatcher struct {
 	// If true, all elements in the value must be matched by matchers. If
 	// false, matchers can be a subset.
 	matchAll bool
+
+	// If true, the roles are reversed: all elements in the value must be
+	// matched by matchers, but not all matchers need to be used.
+	subset bool
+
+	// If true, several matchers can be matched by the same element of the
+	// value.
+	reuse bool
+
+	// If true, maps are matched by their key-value pairs rather than their
+	// values.
+	entries bool
+}
+
+// Returns the elements of `x` to match against, or false if it isn't a
+// collection.
+func (m unorderedMatcher) collect(x any) ([]element, bool) {
+	if r := reflect.ValueOf(x); m.entries && r.Kind() == reflect.Map {
+		return mapEntries(r), true
+	}
+	return collectionElements(x)
+}
+
+// Returns why a value with `n` elements can't match, or "" if it might. With
+// IgnoreMultiplicity(), any number of values can match the same element, and
+// vice versa, so the length doesn't matter.
+func (m unorderedMatcher) lengthProblem(n int) string {
+	switch {
+	case m.reuse:
+		return ""
+	case m.matchAll && n != len(m.elements):
+		return fmt.Sprintf("%d elements expected but got %d", len(m.elements), n)
+	case m.subset && n > len(m.elements):
+		return fmt.Sprintf("at most %d elements expected but got %d", len(m.elements), n)
+	case !m.subset && n < len(m.elements):
+		return fmt.Sprintf("at least %d elements expected but got %d", len(m.elements), n)
+	}
+	return ""
 }
 
 func (m unorderedMatcher) Matches(x any) bool {
-	r := reflect.ValueOf(x)
-	switch r.Kind() {
-	case reflect.Array, reflect.Slice:
-		if m.matchAll && r.Len() != len(m.elements) {
-			return false
-		} else if r.Len() < len(m.elements) {
-			return false
-		}
+	elements, ok := m.collect(x)
+	if !ok {
+		return false
+	}
+	if m.lengthProblem(len(elements)) != "" {
+		return false
+	}
 
-		// Initialize adjacency graph based on whether each value satisfies each
-		// matcher.
-		matchMatrix := make([][]bool, len(m.elements))
-		for i := range m.elements {
-			matchMatrix[i] = make([]bool, r.Len())
-			for j := range len(matchMatrix[i]) {
-				matchMatrix[i][j] = m.elements[i].Matches(r.Index(j).Interface())
-			}
+	// Initialize adjacency graph based on whether each value satisfies each
+	// matcher.
+	matchMatrix := make([][]bool, len(m.elements))
+	for i := range m.elements {
+		matchMatrix[i] = make([]bool, len(elements))
+		for j := range len(matchMatrix[i]) {
+			matchMatrix[i][j] = m.elements[i].Matches(elements[j].value)
 		}
+	}
 
-		// Short-circuit by checking if any matchers (and values, if we need a
-		// full bijection) are unmatchable.
-		noMatchMatchers, noMatchValues := validateMatchMatrix(matchMatrix, r.Len())
-		if len(noMatchMatchers) > 0 {
-			return false
-		}
-		if m.matchAll && len(noMatchValues) > 0 {
-			return false
-		}
+	// Short-circuit by checking if any matchers (and values, if we need a
+	// full bijection) are unmatchable.
+	noMatchMatchers, noMatchValues := validateMatchMatrix(matchMatrix, len(elements))
+	if !m.subset && len(noMatchMatchers) > 0 {
+		return false
+	}
+	if (m.matchAll || m.subset) && len(noMatchValues) > 0 {
+		return false
+	}
+	if m.reuse {
+		return true
+	}
 
-		g := newMatcherFlowGraph(matchMatrix)
-		g.Solve()
+	g := newMatcherFlowGraph(matchMatrix)
+	g.Solve()
 
-		return g.matchersMatched == len(m.elements)
-	default:
-		return false
+	if m.subset {
+		return g.matchersMatched == len(elements)
 	}
+	return g.matchersMatched == len(m.elements)
 }
 
 func (m unorderedMatcher) String() string {
@@ -462,8 +946,16 @@ func (m unorderedMatcher) String() string {
 		elemStrings[i] = el.String()
 	}
 	var prefix string
-	if m.matchAll {
+	if m.matchAll && m.reuse {
+		prefix = "has elements matching (in any order, not necessarily distinct)"
+	} else if m.matchAll {
 		prefix = "has elements matching (in any order)"
+	} else if m.subset && m.reuse {
+		prefix = "has elements each matching one of"
+	} else if m.subset {
+		prefix = "has elements each matching a different one of"
+	} else if m.reuse {
+		prefix = "contains elements (not necessarily distinct) matching"
 	} else {
 		prefix = "contains elements matching"
 	}
@@ -474,73 +966,75 @@ func (m unorderedMatcher) String() string {
 }
 
 func (m unorderedMatcher) ExplainFailure(val any) (string, bool) {
-	r := reflect.ValueOf(val)
-	switch r.Kind() {
-	case reflect.Array, reflect.Slice:
-		// For legibility reasons, this function is intentionally very similar
-		// to Matches(). It will return increasingly specific error messages as
-		// the matcher is closer and closer to being satisfied.
+	// For legibility reasons, this function is intentionally very similar to
+	// Matches(). It will return increasingly specific error messages as the
+	// matcher is closer and closer to being satisfied.
+	elements, ok := m.collect(val)
+	if !ok {
+		return notIterable(val), true
+	}
 
-		if m.matchAll && r.Len() != len(m.elements) {
-			return fmt.Sprintf("%d elements expected but got %d", len(m.elements), r.Len()), true
-		} else if r.Len() < len(m.elements) {
-			return fmt.Sprintf("at least %d elements expected but got %d", len(m.elements), r.Len()), true
-		}
+	if problem := m.lengthProblem(len(elements)); problem != "" {
+		return problem, true
+	}
 
-		// Initialize adjacency graph based on whether each value satisfies each
-		// matcher.
-		matchMatrix := make([][]bool, len(m.elements))
-		for i := range m.elements {
-			matchMatrix[i] = make([]bool, r.Len())
-			for j := range len(matchMatrix[i]) {
-				matchMatrix[i][j] = m.elements[i].Matches(r.Index(j).Interface())
-			}
+	// Initialize adjacency graph based on whether each value satisfies each
+	// matcher.
+	matchMatrix := make([][]bool, len(m.elements))
+	for i := range m.elements {
+		matchMatrix[i] = make([]bool, len(elements))
+		for j := range len(matchMatrix[i]) {
+			matchMatrix[i][j] = m.elements[i].Matches(elements[j].value)
 		}
+	}
 
-		// Short-circuit by checking if any matchers are unmatchable.
-		noMatchMatchers, noMatchValues := validateMatchMatrix(matchMatrix, r.Len())
-		noMatchProblems := make([]string, 0)
+	// Short-circuit by checking if any matchers are unmatchable.
+	noMatchMatchers, noMatchValues := validateMatchMatrix(matchMatrix, len(elements))
+	noMatchProblems := make([]string, 0)
+	if !m.subset {
 		for _, badMatcher := range noMatchMatchers {
 			noMatchProblems = append(
 				noMatchProblems,
 				fmt.Sprintf("matcher %d matches no elements (wanted %s)",
 					badMatcher, m.elements[badMatcher].String()))
 		}
+	}
 
-		if m.matchAll {
-			for _, badValue := range noMatchValues {
-				noMatchProblems = append(
-					noMatchProblems,
-					fmt.Sprintf("value %d matches no matchers", badValue))
-			}
+	if m.matchAll || m.subset {
+		for _, badValue := range noMatchValues {
+			noMatchProblems = append(
+				noMatchProblems,
+				fmt.Sprintf("%s matches no matchers", elements[badValue].valueLabel()))
 		}
+	}
 
-		if len(noMatchProblems) > 0 {
-			return strings.Join(noMatchProblems, "; "), true
-		}
+	if len(noMatchProblems) > 0 {
+		return strings.Join(noMatchProblems, "; "), true
+	}
+	if m.reuse {
+		return "", false
+	}
 
-		g := newMatcherFlowGraph(matchMatrix)
-		g.Solve()
+	g := newMatcherFlowGraph(matchMatrix)
+	g.Solve()
 
-		var problem string
-		if m.matchAll {
-			problem = fmt.Sprintf("no permutation could pair all matchers and values, closest match is %d/%d with ", g.matchersMatched, len(m.elements))
-		} else {
-			problem = fmt.Sprintf("no permutation could satisfy all matchers, closest match is %d/%d with ", g.matchersMatched, len(m.elements))
-		}
+	var problem string
+	if m.matchAll {
+		problem = fmt.Sprintf("no permutation could pair all matchers and values, closest match is %d/%d with ", g.matchersMatched, len(m.elements))
+	} else if m.subset {
+		problem = fmt.Sprintf("no permutation could match all values, closest match is %d/%d with ", g.matchersMatched, len(elements))
+	} else {
+		problem = fmt.Sprintf("no permutation could satisfy all matchers, closest match is %d/%d with ", g.matchersMatched, len(m.elements))
+	}
 
-		matches := make([]string, 0)
-		for i := range g.valToMatcher {
-			if g.valToMatcher[i] != -1 {
-				matches = append(matches, fmt.Sprintf("value %d -> matcher %d", i, g.valToMatcher[i]))
-			}
+	matches := make([]string, 0)
+	for i := range g.valToMatcher {
+		if g.valToMatcher[i] != -1 {
+			matches = append(matches, fmt.Sprintf("%s -> matcher %d", elements[i].valueLabel(), g.valToMatcher[i]))
 		}
-		problem = problem + strings.Join(matches, "; ")
-		return problem, true
-
-	default:
-		return fmt.Sprintf("type %T isn't iterable", val), true
 	}
+	problem = problem + strings.Join(matches, "; ")
+	return problem, true
 }
 
 func validateMatchMatrix(matchMatrix [][]bool, width int) ([]int, []int) {
@@ -613,8 +1107,9 @@ func (g *matcherFlowGraph) Solve() {
 	for matcher := range len(g.matchMatrix) {
 		// Try to find a matching for this matcher.
 		//
-		// 'visited' prevents cycles in this particular iteration.
-		visited := make([]bool, len(g.matchMatrix))
+		// 'visited' prevents cycles in this particular iteration. It's
+		// indexed by value, not by matcher.
+		visited := make([]bool, len(g.valToMatcher))
 		g.tryAssign(matcher, &visited)
 	}
 
@@ -690,15 +1185,7 @@ func (m orderedMatcher) ExplainFailure(val any) (string, bool) {
 		}
 		for i := range r.Len() {
 			if !m.elements[i].Matches(r.Index(i).Interface()) {
-				var explanation string
-				var useE bool
-				if explainer, ok := m.elements[i].(MismatchExplainer); ok {
-					explanation, useE = explainer.ExplainFailure(r.Index(i).Interface())
-				}
-				if !useE {
-					explanation = "doesn't match"
-				}
-
+				explanation := explainMismatch(m.elements[i], r.Index(i).Interface())
 				parts = append(parts, fmt.Sprintf("element %d: %s", i, explanation))
 			}
 		}
@@ -718,3 +1205,1014 @@ func (m orderedMatcher) String() string {
 	}
 	return fmt.Sprintf("has elements matching [%s]", strings.Join(elemStrings, "; "))
 }
+
+// Matches slices or arrays whose elements are in ascending order, allowing
+// duplicates. Elements must be numbers, strings, time.Times, or math/big
+// numbers; use SortedBy() for other orderings.
+//
+// Examples:
+//
+//	ExpectThat(t, []int{1, 2, 2, 5}, IsSorted())
+//	ExpectThat(t, []string{"apple", "banana"}, IsSorted())
+//	ExpectThat(t, []int{3, 1, 2}, Not(IsSorted()))
+func IsSorted() Matcher {
+	return sortedMatcher{}
+}
+
+// Matches slices or arrays whose elements are in the order defined by `less`,
+// which reports whether `a` must come before `b`. Equal elements may appear in
+// any order. Elements that aren't assignable to T never match.
+//
+// Examples:
+//
+//	byAge := func(a, b Person) bool { return a.Age < b.Age }
+//	ExpectThat(t, people, SortedBy(byAge))
+//	ExpectThat(t, []int{3, 2, 1}, SortedBy(func(a, b int) bool { return a > b }))
+func SortedBy[T any](less func(a, b T) bool) Matcher {
+	return sortedMatcher{outOfOrder: func(prev, cur reflect.Value) (bool, string) {
+		prevT, ok := valueAs(prev.Interface(), reflect.TypeFor[T]())
+		if !ok {
+			return false, fmt.Sprintf("element is of type %s, not %s", prev.Type(), reflect.TypeFor[T]())
+		}
+		curT, ok := valueAs(cur.Interface(), reflect.TypeFor[T]())
+		if !ok {
+			return false, fmt.Sprintf("element is of type %s, not %s", cur.Type(), reflect.TypeFor[T]())
+		}
+		return less(curT.Interface().(T), prevT.Interface().(T)), ""
+	}}
+}
+
+type sortedMatcher struct {
+	// Reports whether `cur` must come before `prev`, or a problem if they can't
+	// be compared. If nil, the natural ordering is used.
+	outOfOrder func(prev, cur reflect.Value) (bool, string)
+}
+
+// Returns the index of the first element that is out of order with respect to
+// the one before it, or -1 if the value is sorted. If the value can't be
+// checked at all, returns a description of the problem.
+func (m sortedMatcher) firstUnsorted(x any) (int, string) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return -1, fmt.Sprintf("type %T isn't iterable", x)
+	}
+
+	outOfOrder := m.outOfOrder
+	if outOfOrder == nil {
+		outOfOrder = naturallyOutOfOrder
+	}
+	for i := 1; i < r.Len(); i++ {
+		unsorted, problem := outOfOrder(r.Index(i-1), r.Index(i))
+		if problem != "" {
+			return -1, fmt.Sprintf("elements %d and %d can't be compared: %s", i-1, i, problem)
+		}
+		if unsorted {
+			return i, ""
+		}
+	}
+	return -1, ""
+}
+
+func (m sortedMatcher) Matches(x any) bool {
+	i, problem := m.firstUnsorted(x)
+	return i < 0 && problem == ""
+}
+
+func (m sortedMatcher) String() string {
+	if m.outOfOrder == nil {
+		return "is sorted in ascending order"
+	}
+	return "is sorted by the given ordering"
+}
+
+func (m sortedMatcher) ExplainFailure(x any) (string, bool) {
+	i, problem := m.firstUnsorted(x)
+	if problem != "" {
+		return problem, true
+	}
+	if i < 0 {
+		return "", false
+	}
+	r := reflect.ValueOf(x)
+	return fmt.Sprintf("elements %d and %d are out of order (%v, then %v)",
+		i-1, i, r.Index(i-1), r.Index(i)), true
+}
+
+// Reports whether `a` is greater than `b` in the natural ordering of their
+// type.
+func naturallyOutOfOrder(a, b reflect.Value) (bool, string) {
+	a, b = unwrapInterface(a), unwrapInterface(b)
+	if !a.IsValid() || !b.IsValid() {
+		return false, "nil can't be compared"
+	}
+	c, ok := compareValues(a, b)
+	if !ok {
+		if a.Type() == b.Type() {
+			return false, fmt.Sprintf("type %s isn't ordered", a.Type())
+		}
+		return false, fmt.Sprintf("types %s and %s can't be compared", a.Type(), b.Type())
+	}
+	return c > 0, ""
+}
+
+// Compares two values of the same ordered type: numbers, strings, time.Times,
+// or math/big numbers (which can also be compared to each other).
+func compareValues(a, b reflect.Value) (int, bool) {
+	a, b = unwrapInterface(a), unwrapInterface(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+	if a.CanInterface() && b.CanInterface() && isBig(a.Interface()) && isBig(b.Interface()) {
+		ok, c := compareBig(a.Interface(), b.Interface())
+		return c, ok
+	}
+	if a.Type() != b.Type() {
+		return 0, false
+	}
+	if aTime, ok := a.Interface().(time.Time); ok {
+		return aTime.Compare(b.Interface().(time.Time)), true
+	}
+	switch {
+	case a.CanInt():
+		return cmp.Compare(a.Int(), b.Int()), true
+	case a.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case a.CanFloat():
+		return cmp.Compare(a.Float(), b.Float()), true
+	case a.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String()), true
+	default:
+		return 0, false
+	}
+}
+
+// Returns the dynamic value held by an interface value, or the invalid Value
+// if it holds nil. Other values are returned unchanged.
+func unwrapInterface(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+// Matches slices and arrays where every element fulfills `inner`, maps where
+// every value does, and channels where every value currently buffered does.
+// Empty collections always match.
+//
+// Examples:
+//
+//	ExpectThat(t, []int{1, 2, 3}, Each(Gt(0)))
+//	ExpectThat(t, map[string]string{"a": "x@example.com"}, Each(HasSubstr("@example.com")))
+//	ExpectThat(t, users, Each(Field("Active", true)))
+//	ExpectThat(t, []int{1, -2, 3}, Not(Each(Gt(0))))
+func Each(inner any) Matcher {
+	return eachMatcher{AsMatcher(inner)}
+}
+
+type eachMatcher struct {
+	inner Matcher
+}
+
+func (m eachMatcher) Matches(x any) bool {
+	elements, ok := collectionElements(x)
+	if !ok {
+		return false
+	}
+	for _, el := range elements {
+		if !m.inner.Matches(el.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m eachMatcher) String() string {
+	return fmt.Sprintf("each element %s", m.inner.String())
+}
+
+func (m eachMatcher) ExplainFailure(x any) (string, bool) {
+	elements, ok := collectionElements(x)
+	if !ok {
+		return notIterable(x), true
+	}
+	var problems []string
+	for _, el := range elements {
+		if m.inner.Matches(el.value) {
+			continue
+		}
+		problem := fmt.Sprintf("%s is %s", el.label(), formatGot(el.value, m.inner))
+		problem = appendExplanation(problem, m.inner, el.value)
+		problems = append(problems, problem)
+	}
+	if len(problems) == 0 {
+		return "", false
+	}
+	return strings.Join(problems, "; "), true
+}
+
+// An element of a collection, along with where it is: its index in a slice,
+// array or channel, or its key in a map.
+type element struct {
+	position string
+	source   elementSource
+	value    any
+}
+
+type elementSource int
+
+const (
+	inSlice elementSource = iota
+	inMap
+	inMapEntry
+	inChannel
+)
+
+func (el element) label() string {
+	switch el.source {
+	case inMap:
+		return "value at key " + el.position
+	case inMapEntry:
+		return "entry at key " + el.position
+	case inChannel:
+		return "received value " + el.position
+	}
+	return "element " + el.position
+}
+
+// Like label(), but elements of slices are called values, as the unordered
+// matchers have always reported them.
+func (el element) valueLabel() string {
+	if el.source == inSlice {
+		return "value " + el.position
+	}
+	return el.label()
+}
+
+// Returns the elements of a slice or array, the values of a map (ordered by
+// key, if possible), or the values buffered in a channel. See
+// channelElements() for which channels are iterable.
+func collectionElements(x any) ([]element, bool) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		elements := make([]element, r.Len())
+		for i := range r.Len() {
+			elements[i] = element{strconv.Itoa(i), inSlice, r.Index(i).Interface()}
+		}
+		return elements, true
+	case reflect.Map:
+		keys := sortedMapKeys(r)
+		elements := make([]element, len(keys))
+		for i, k := range keys {
+			elements[i] = element{fmt.Sprint(k), inMap, r.MapIndex(k).Interface()}
+		}
+		return elements, true
+	case reflect.Chan:
+		return channelElements(r)
+	default:
+		return nil, false
+	}
+}
+
+// Returns the values buffered in a channel, which are received from it and
+// then sent back, so that the channel is left as it was. This is only
+// possible for buffered channels that can be both received from and sent to,
+// and that aren't closed with values still in them, since those values
+// couldn't be sent back. Other channels aren't iterable.
+//
+// The channel mustn't be used by other goroutines while it's being matched.
+// If a value can't be sent back because of that, this panics rather than
+// losing it silently.
+func channelElements(r reflect.Value) ([]element, bool) {
+	if r.Type().ChanDir() != reflect.BothDir || r.Cap() == 0 {
+		return nil, false
+	}
+	// A zero value is sent first, to find out whether the channel is closed
+	// without receiving from it. If it's sent, it's received after the
+	// buffered values, and dropped.
+	var sent bool
+	if _, closed := recoverFrom(func() { sent = r.TrySend(reflect.Zero(r.Type().Elem())) }); closed {
+		return nil, r.Len() == 0
+	}
+	n := r.Len()
+	if sent {
+		n--
+	}
+	elements := make([]element, n)
+	values := make([]reflect.Value, n)
+	for i := range n {
+		v, ok := r.TryRecv()
+		if !ok {
+			panic(fmt.Sprintf("gotest: %s was received from while its values were being matched", r.Type()))
+		}
+		values[i] = v
+		elements[i] = element{strconv.Itoa(i), inChannel, v.Interface()}
+	}
+	if sent {
+		r.TryRecv()
+	}
+	for _, v := range values {
+		if !r.TrySend(v) {
+			panic(fmt.Sprintf("gotest: %s was sent to while its values were being matched, so %v couldn't be put back", r.Type(), v))
+		}
+	}
+	return elements, true
+}
+
+// Describes why `x` isn't iterable.
+func notIterable(x any) string {
+	r := reflect.ValueOf(x)
+	if r.Kind() != reflect.Chan {
+		return fmt.Sprintf("type %T isn't iterable", x)
+	}
+	switch {
+	case r.Type().ChanDir() != reflect.BothDir:
+		return fmt.Sprintf("type %T isn't iterable: only channels that can be both received from and sent to are, so that their values can be put back", x)
+	case r.Cap() == 0:
+		return fmt.Sprintf("type %T isn't iterable: the channel is unbuffered", x)
+	}
+	return fmt.Sprintf("type %T isn't iterable: the channel is closed, so its values can't be put back", x)
+}
+
+// Returns the key-value pairs of a map as two-element arrays, in the form
+// KeyVal() matches, ordered by key if possible.
+func mapEntries(r reflect.Value) []element {
+	keys := sortedMapKeys(r)
+	elements := make([]element, len(keys))
+	for i, k := range keys {
+		elements[i] = element{fmt.Sprint(k), inMapEntry, [2]any{k.Interface(), r.MapIndex(k).Interface()}}
+	}
+	return elements
+}
+
+// Matches slices and arrays where the number of elements fulfilling `inner`
+// itself fulfills `count`, and likewise for the values of maps. Both arguments
+// can be matchers or exact values.
+//
+// Examples:
+//
+//	ExpectThat(t, []int{5, 11, 12, 20}, ContainsN(Gt(10), 3))
+//	ExpectThat(t, logLines, ContainsN(HasSubstr("ERROR"), Ge(1)))
+//	ExpectThat(t, logLines, ContainsN(HasSubstr("PANIC"), 0))
+func ContainsN(inner any, count any) Matcher {
+	return containsNMatcher{AsMatcher(inner), AsMatcher(count)}
+}
+
+type containsNMatcher struct {
+	inner Matcher
+	count Matcher
+}
+
+// Returns the elements that fulfill the inner matcher.
+func (m containsNMatcher) matching(x any) ([]element, bool) {
+	elements, ok := collectionElements(x)
+	if !ok {
+		return nil, false
+	}
+	var matched []element
+	for _, el := range elements {
+		if m.inner.Matches(el.value) {
+			matched = append(matched, el)
+		}
+	}
+	return matched, true
+}
+
+func (m containsNMatcher) Matches(x any) bool {
+	matched, ok := m.matching(x)
+	return ok && m.count.Matches(len(matched))
+}
+
+func (m containsNMatcher) String() string {
+	return fmt.Sprintf("has a number of elements which %s that %s",
+		m.inner.String(), m.count.String())
+}
+
+func (m containsNMatcher) ExplainFailure(x any) (string, bool) {
+	matched, ok := m.matching(x)
+	if !ok {
+		return notIterable(x), true
+	}
+	switch len(matched) {
+	case 0:
+		return "no elements matched", true
+	case 1:
+		return fmt.Sprintf("%s matched", matched[0].label()), true
+	}
+	positions := make([]string, len(matched))
+	for i, el := range matched {
+		positions[i] = el.position
+	}
+	if matched[0].source == inMap {
+		return fmt.Sprintf("%d values matched (keys %s)", len(matched), strings.Join(positions, ", ")), true
+	}
+	return fmt.Sprintf("%d elements matched (%s)", len(matched), strings.Join(positions, ", ")), true
+}
+
+// Tests that a slice or array contains elements matching each of `elements`,
+// in the same relative order. Other elements may appear before, between, and
+// after them.
+//
+// Each element can be either an exact value (tested by equality) or a
+// matcher.
+//
+// Examples:
+//
+//	ExpectThat(t, events, ContainsSubsequence("start", "commit", "stop"))
+//	ExpectThat(t, []int{1, 5, 2, 6}, ContainsSubsequence(1, 2, Gt(5)))
+//	ExpectThat(t, []int{1, 2, 3}, Not(ContainsSubsequence(3, 1)))
+func ContainsSubsequence(elements ...any) Matcher {
+	matchers := make([]Matcher, len(elements))
+	for i, el := range elements {
+		matchers[i] = AsMatcher(el)
+	}
+	return subsequenceMatcher{matchers}
+}
+
+type subsequenceMatcher struct {
+	elements []Matcher
+}
+
+// Matches the matchers against the earliest possible elements. Returns the
+// index of the first matcher that couldn't be matched (or -1 if all were), and
+// the index of the element after which the search for it started.
+func (m subsequenceMatcher) search(r reflect.Value) (missing, after int) {
+	next := 0
+	for i, matcher := range m.elements {
+		found := false
+		for ; next < r.Len(); next++ {
+			if matcher.Matches(r.Index(next).Interface()) {
+				found = true
+				next++
+				break
+			}
+		}
+		if !found {
+			return i, next
+		}
+	}
+	return -1, next
+}
+
+func (m subsequenceMatcher) Matches(x any) bool {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		missing, _ := m.search(r)
+		return missing < 0
+	default:
+		return false
+	}
+}
+
+func (m subsequenceMatcher) String() string {
+	elemStrings := make([]string, len(m.elements))
+	for i, el := range m.elements {
+		elemStrings[i] = el.String()
+	}
+	return fmt.Sprintf("contains a subsequence matching [%s]", strings.Join(elemStrings, "; "))
+}
+
+func (m subsequenceMatcher) ExplainFailure(x any) (string, bool) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return fmt.Sprintf("type %T isn't iterable", x), true
+	}
+	missing, _ := m.search(r)
+	if missing < 0 {
+		return "", false
+	}
+	if missing == 0 {
+		return fmt.Sprintf("matcher 0 matches no elements (wanted %s)", m.elements[0].String()), true
+	}
+
+	// Find where the previous matcher was satisfied, to show where the search
+	// gave up.
+	prev := subsequenceMatcher{m.elements[:missing]}
+	_, after := prev.search(r)
+	return fmt.Sprintf("matcher %d matches no elements after element %d, where matcher %d matched (wanted %s)",
+		missing, after-1, missing-1, m.elements[missing].String()), true
+}
+
+// Tests that a slice or array contains elements matching each of `elements`
+// as a contiguous run, in order. Other elements may appear before and after
+// the run, but not within it.
+//
+// Each element can be either an exact value (tested by equality) or a
+// matcher.
+//
+// Examples:
+//
+//	ExpectThat(t, frames, ContainsConsecutive("SYN", "SYN-ACK", "ACK"))
+//	ExpectThat(t, []int{1, 2, 3, 4}, ContainsConsecutive(2, Gt(2)))
+//	ExpectThat(t, []int{1, 2, 3, 4}, Not(ContainsConsecutive(1, 3)))
+func ContainsConsecutive(elements ...any) Matcher {
+	matchers := make([]Matcher, len(elements))
+	for i, el := range elements {
+		matchers[i] = AsMatcher(el)
+	}
+	return consecutiveMatcher{matchers}
+}
+
+type consecutiveMatcher struct {
+	elements []Matcher
+}
+
+// Returns the number of matchers satisfied by the run starting at `start`.
+func (m consecutiveMatcher) countAt(r reflect.Value, start int) int {
+	count := 0
+	for i, matcher := range m.elements {
+		if matcher.Matches(r.Index(start + i).Interface()) {
+			count++
+		}
+	}
+	return count
+}
+
+func (m consecutiveMatcher) Matches(x any) bool {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		for start := 0; start+len(m.elements) <= r.Len(); start++ {
+			if m.countAt(r, start) == len(m.elements) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (m consecutiveMatcher) String() string {
+	elemStrings := make([]string, len(m.elements))
+	for i, el := range m.elements {
+		elemStrings[i] = el.String()
+	}
+	return fmt.Sprintf("contains a consecutive run matching [%s]", strings.Join(elemStrings, "; "))
+}
+
+func (m consecutiveMatcher) ExplainFailure(x any) (string, bool) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return fmt.Sprintf("type %T isn't iterable", x), true
+	}
+	if r.Len() < len(m.elements) {
+		return fmt.Sprintf("at least %d elements expected but got %d", len(m.elements), r.Len()), true
+	}
+
+	// Find the run that satisfies the most matchers, preferring earlier ones.
+	best, bestCount := 0, -1
+	for start := 0; start+len(m.elements) <= r.Len(); start++ {
+		if count := m.countAt(r, start); count > bestCount {
+			best, bestCount = start, count
+		}
+	}
+	if bestCount == len(m.elements) {
+		return "", false
+	}
+
+	parts := []string{}
+	for i, matcher := range m.elements {
+		val := r.Index(best + i).Interface()
+		if !matcher.Matches(val) {
+			parts = append(parts, fmt.Sprintf("element %d: %s", best+i, explainMismatch(matcher, val)))
+		}
+	}
+	return fmt.Sprintf("closest run starts at element %d and satisfies %d/%d matchers, but %s",
+		best, bestCount, len(m.elements), strings.Join(parts, "; ")), true
+}
+
+// Tests that a slice or array begins with elements matching `elements`, in
+// order. Any elements may follow them. Values that are too short don't match.
+//
+// Each element can be either an exact value (tested by equality) or a
+// matcher.
+//
+// Examples:
+//
+//	ExpectThat(t, args, SliceStartsWith("git", "commit"))
+//	ExpectThat(t, []int{1, 2, 3}, SliceStartsWith(1, Lt(5)))
+//	ExpectThat(t, []int{1}, Not(SliceStartsWith(1, 2)))
+func SliceStartsWith(elements ...any) Matcher {
+	matchers := make([]Matcher, len(elements))
+	for i, el := range elements {
+		matchers[i] = AsMatcher(el)
+	}
+	return sliceEndMatcher{matchers, false}
+}
+
+// Tests that a slice or array ends with elements matching `elements`, in
+// order. Any elements may precede them. Values that are too short don't match.
+//
+// Each element can be either an exact value (tested by equality) or a
+// matcher.
+//
+// Examples:
+//
+//	ExpectThat(t, path, SliceEndsWith("config", "app.yaml"))
+//	ExpectThat(t, []int{1, 2, 3}, SliceEndsWith(Gt(1), 3))
+//	ExpectThat(t, []int{1, 2, 3}, Not(SliceEndsWith(2)))
+func SliceEndsWith(elements ...any) Matcher {
+	matchers := make([]Matcher, len(elements))
+	for i, el := range elements {
+		matchers[i] = AsMatcher(el)
+	}
+	return sliceEndMatcher{matchers, true}
+}
+
+type sliceEndMatcher struct {
+	elements []Matcher
+
+	// If true, match the last elements of the value; otherwise, the first.
+	atEnd bool
+}
+
+// Returns the index of the value's element corresponding to matcher `i`.
+func (m sliceEndMatcher) index(r reflect.Value, i int) int {
+	if m.atEnd {
+		return r.Len() - len(m.elements) + i
+	}
+	return i
+}
+
+func (m sliceEndMatcher) Matches(x any) bool {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		if r.Len() < len(m.elements) {
+			return false
+		}
+		for i, matcher := range m.elements {
+			if !matcher.Matches(r.Index(m.index(r, i)).Interface()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (m sliceEndMatcher) String() string {
+	elemStrings := make([]string, len(m.elements))
+	for i, el := range m.elements {
+		elemStrings[i] = el.String()
+	}
+	verb := "starts"
+	if m.atEnd {
+		verb = "ends"
+	}
+	return fmt.Sprintf("%s with elements matching [%s]", verb, strings.Join(elemStrings, "; "))
+}
+
+func (m sliceEndMatcher) ExplainFailure(x any) (string, bool) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return fmt.Sprintf("type %T isn't iterable", x), true
+	}
+	if r.Len() < len(m.elements) {
+		return fmt.Sprintf("at least %d elements expected but got %d", len(m.elements), r.Len()), true
+	}
+	parts := []string{}
+	for i, matcher := range m.elements {
+		idx := m.index(r, i)
+		if val := r.Index(idx).Interface(); !matcher.Matches(val) {
+			parts = append(parts, fmt.Sprintf("element %d: %s", idx, explainMismatch(matcher, val)))
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "; "), true
+}
+
+// Matches slices and arrays whose elements are all distinct. Elements are
+// compared with the same semantics as Eq(), so protos and types with Equal
+// methods are handled.
+//
+// Examples:
+//
+//	ExpectThat(t, ids, HasNoDuplicates())
+//	ExpectThat(t, []int{1, 2, 3}, HasNoDuplicates())
+//	ExpectThat(t, []string{"a", "b", "a"}, Not(HasNoDuplicates()))
+func HasNoDuplicates() Matcher {
+	callerPkg, ok := GetCallerPkg()
+	if !ok {
+		panic("HasNoDuplicates: unable to determine caller package")
+	}
+	opts := defaultEqOptions(callerPkg)
+	return noDuplicatesMatcher{func(a, b any) bool {
+		return eqMatcher{val: a, opts: opts}.Matches(b)
+	}}
+}
+
+type noDuplicatesMatcher struct {
+	equal func(a, b any) bool
+}
+
+// Returns groups of indices of equal elements, for each element that appears
+// more than once.
+func (m noDuplicatesMatcher) duplicates(r reflect.Value) [][]int {
+	var groups [][]int
+	seen := make([]bool, r.Len())
+	for i := range r.Len() {
+		if seen[i] {
+			continue
+		}
+		group := []int{i}
+		for j := i + 1; j < r.Len(); j++ {
+			if !seen[j] && m.equal(r.Index(i).Interface(), r.Index(j).Interface()) {
+				seen[j] = true
+				group = append(group, j)
+			}
+		}
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func (m noDuplicatesMatcher) Matches(x any) bool {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+		return len(m.duplicates(r)) == 0
+	default:
+		return false
+	}
+}
+
+func (m noDuplicatesMatcher) String() string {
+	return "has no duplicate elements"
+}
+
+func (m noDuplicatesMatcher) ExplainFailure(x any) (string, bool) {
+	r := reflect.ValueOf(x)
+	switch r.Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return fmt.Sprintf("type %T isn't iterable", x), true
+	}
+	groups := m.duplicates(r)
+	if len(groups) == 0 {
+		return "", false
+	}
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		indices := make([]string, len(group))
+		for j, idx := range group {
+			indices[j] = strconv.Itoa(idx)
+		}
+		parts[i] = fmt.Sprintf("%v appears at elements %s",
+			r.Index(group[0]), strings.Join(indices, ", "))
+	}
+	return strings.Join(parts, "; "), true
+}
+
+// Tests that a slice or array has the same length as `expected`, and that
+// each of its elements fulfills the matcher made from the corresponding
+// element of `expected` by `makeMatcher`. This is ElementsAre() for when the
+// expectations are themselves data.
+//
+// Examples:
+//
+//	near := func(want float64) Matcher { return Near(want, 0.001) }
+//	ExpectThat(t, result, Pointwise(near, []float64{0.5, 1.25, 3}))
+//	ExpectThat(t, names, Pointwise(EqualIgnoringCase, []string{"alice", "bob"}))
+func Pointwise[T any](makeMatcher func(expected T) Matcher, expected []T) Matcher {
+	matchers := make([]Matcher, len(expected))
+	for i, el := range expected {
+		matchers[i] = makeMatcher(el)
+	}
+	return orderedMatcher{matchers}
+}
+
+// Matches slices and arrays of slices (or arrays) which, once flattened by
+// one level, fulfill `inner`. Typically `inner` is a container matcher like
+// ElementsAre() or Contains().
+//
+// Elements that aren't themselves slices or arrays (e.g. in a []any) are kept
+// as they are.
+//
+// Examples:
+//
+//	pages := [][]string{{"a", "b"}, {"c"}}
+//	ExpectThat(t, pages, Flattened(ElementsAre("a", "b", "c")))
+//	ExpectThat(t, chunks, Flattened(Len(100)))
+//	ExpectThat(t, [][]int{{1}, {2, 3}}, Flattened(Each(Gt(0))))
+func Flattened(inner any) Matcher {
+	return flattenMatcher{AsMatcher(inner), false}
+}
+
+// Like Flattened(), but flattens all levels of nesting, so that none of the
+// resulting elements are slices or arrays.
+//
+// Examples:
+//
+//	ExpectThat(t, [][][]int{{{1}, {2}}, {{3}}}, FlattenedRecursively([]int{1, 2, 3}))
+//	ExpectThat(t, []any{1, []any{2, []int{3}}}, FlattenedRecursively(ElementsAre(1, 2, 3)))
+func FlattenedRecursively(inner any) Matcher {
+	return flattenMatcher{AsMatcher(inner), true}
+}
+
+type flattenMatcher struct {
+	inner     Matcher
+	recursive bool
+}
+
+// Returns the flattened value, or false if `x` isn't a slice or array.
+func (m flattenMatcher) flatten(x any) (any, bool) {
+	r := reflect.ValueOf(x)
+	if !isSliceOrArray(r) {
+		return nil, false
+	}
+
+	// The result has the most specific element type that all the flattened
+	// elements are known to have.
+	elemType := r.Type().Elem()
+	for isSliceOrArrayType(elemType) {
+		elemType = elemType.Elem()
+		if !m.recursive {
+			break
+		}
+	}
+
+	out := reflect.MakeSlice(reflect.SliceOf(elemType), 0, r.Len())
+	var appendAll func(v reflect.Value, depth int)
+	appendAll = func(v reflect.Value, depth int) {
+		for i := range v.Len() {
+			el := v.Index(i)
+			if inner := unwrapInterface(el); isSliceOrArray(inner) && (m.recursive || depth == 0) {
+				appendAll(inner, depth+1)
+			} else {
+				out = reflect.Append(out, el)
+			}
+		}
+	}
+	appendAll(r, 0)
+	return out.Interface(), true
+}
+
+func isSliceOrArray(r reflect.Value) bool {
+	return r.Kind() == reflect.Slice || r.Kind() == reflect.Array
+}
+
+func isSliceOrArrayType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+}
+
+func (m flattenMatcher) Matches(x any) bool {
+	if flattened, ok := m.flatten(x); ok {
+		return m.inner.Matches(flattened)
+	}
+	return false
+}
+
+func (m flattenMatcher) String() string {
+	if m.recursive {
+		return fmt.Sprintf("when flattened recursively, %s", m.inner.String())
+	}
+	return fmt.Sprintf("when flattened, %s", m.inner.String())
+}
+
+func (m flattenMatcher) ExplainFailure(x any) (string, bool) {
+	flattened, ok := m.flatten(x)
+	if !ok {
+		return fmt.Sprintf("type %T isn't a slice or array", x), true
+	}
+	explanation := fmt.Sprintf("flattened value is %s", formatGot(flattened, m.inner))
+	explanation = appendExplanation(explanation, m.inner, flattened)
+	return explanation, true
+}
+
+// Matches slices and arrays which, when their elements are partitioned by
+// `keyFn`, have groups fulfilling the matchers in `groupMatchers`. Each group
+// is a slice of the elements with that key, in their original order, and is
+// empty if there are no such elements. Groups whose keys don't appear in
+// `groupMatchers` aren't checked.
+//
+// Elements that aren't assignable to T never match.
+//
+// Examples:
+//
+//	level := func(e Event) string { return e.Level }
+//	ExpectThat(t, events, GroupedBy(level, map[string]any{
+//		"error": Each(Field("Code", Gt(500))),
+//		"warn":  Len(2),
+//		"fatal": Empty(),
+//	}))
+//	ExpectThat(t, []int{1, 2, 3, 4}, GroupedBy(func(i int) bool { return i%2 == 0 }, map[bool]any{
+//		true:  []int{2, 4},
+//		false: []int{1, 3},
+//	}))
+func GroupedBy[T any, K comparable](keyFn func(T) K, groupMatchers map[K]any) Matcher {
+	groups := make([]group, 0, len(groupMatchers))
+	for k, v := range groupMatchers {
+		groups = append(groups, group{k, AsMatcher(v)})
+	}
+	slices.SortFunc(groups, func(a, b group) int {
+		return cmp.Compare(fmt.Sprint(a.key), fmt.Sprint(b.key))
+	})
+	return groupedMatcher{
+		keyOf: func(el reflect.Value) (any, string) {
+			elT, ok := valueAs(el.Interface(), reflect.TypeFor[T]())
+			if !ok {
+				return nil, fmt.Sprintf("is of type %T, not %s", el.Interface(), reflect.TypeFor[T]())
+			}
+			return keyFn(elT.Interface().(T)), ""
+		},
+		groups: groups,
+	}
+}
+
+type group struct {
+	key     any
+	matcher Matcher
+}
+
+type groupedMatcher struct {
+	// Returns the key of an element, or a problem if it can't be computed.
+	keyOf  func(el reflect.Value) (any, string)
+	groups []group
+}
+
+// Returns the elements of `x` with each key in `m.groups`, or a description
+// of why they can't be found.
+func (m groupedMatcher) partition(x any) (map[any]any, string) {
+	r := reflect.ValueOf(x)
+	if !isSliceOrArray(r) {
+		return nil, fmt.Sprintf("type %T isn't iterable", x)
+	}
+
+	partitions := make(map[any]reflect.Value, len(m.groups))
+	for _, g := range m.groups {
+		partitions[g.key] = reflect.MakeSlice(reflect.SliceOf(r.Type().Elem()), 0, 0)
+	}
+	for i := range r.Len() {
+		key, problem := m.keyOf(r.Index(i))
+		if problem != "" {
+			return nil, fmt.Sprintf("element %d %s", i, problem)
+		}
+		if p, ok := partitions[key]; ok {
+			partitions[key] = reflect.Append(p, r.Index(i))
+		}
+	}
+
+	out := make(map[any]any, len(partitions))
+	for k, p := range partitions {
+		out[k] = p.Interface()
+	}
+	return out, ""
+}
+
+func (m groupedMatcher) Matches(x any) bool {
+	partitions, problem := m.partition(x)
+	if problem != "" {
+		return false
+	}
+	for _, g := range m.groups {
+		if !g.matcher.Matches(partitions[g.key]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m groupedMatcher) String() string {
+	parts := make([]string, len(m.groups))
+	for i, g := range m.groups {
+		parts[i] = fmt.Sprintf("group %v %s", g.key, g.matcher.String())
+	}
+	return fmt.Sprintf("when grouped, has [%s]", strings.Join(parts, "; "))
+}
+
+func (m groupedMatcher) ExplainFailure(x any) (string, bool) {
+	partitions, problem := m.partition(x)
+	if problem != "" {
+		return problem, true
+	}
+	var problems []string
+	for _, g := range m.groups {
+		members := partitions[g.key]
+		if g.matcher.Matches(members) {
+			continue
+		}
+		problem := fmt.Sprintf("group %v is %s", g.key, formatGot(members, g.matcher))
+		problem = appendExplanation(problem, g.matcher, members)
+		problems = append(problems, problem)
+	}
+	if len(problems) == 0 {
+		return "", false
+	}
+	return strings.Join(problems, "; "), true
+}
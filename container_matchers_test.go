@@ -1,9 +1,12 @@
 package gotest
 
 import (
+	"fmt"
+	"math/big"
 	"math/rand/v2"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestElementsAre(t *testing.T) {
@@ -149,6 +152,31 @@ func TestElementsAreUnordered(t *testing.T) {
 	))
 }
 
+func TestElementsAreSlice(t *testing.T) {
+	want := []string{"a", "b", "c"}
+	ExpectThat(t, []string{"a", "b", "c"}, ElementsAreSlice(want))
+	ExpectThat(t, []string{"a", "b"}, ElementsAreSlice([]Matcher{Eq("a"), Len(1)}))
+	ExpectThat(t, []int{1, 5}, ElementsAreSlice([]any{1, Gt(2)}))
+	ExpectThat(t, []int{}, ElementsAreSlice([]int{}))
+	ExpectThat(t, []string{"b", "a", "c"}, Not(ElementsAreSlice(want)))
+
+	r := &testReporter{}
+	ExpectThat(r, []int{1, 5}, ElementsAreSlice([]any{1, Gt(6)}))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has elements matching [is equal to 1 (int); is greater than 6 (int)]",
+		"  Got: [1 5] ([]int)",
+		"  ...where element 1: doesn't match",
+	}, "\n"))
+}
+
+func TestUnorderedElementsAreSlice(t *testing.T) {
+	want := []string{"a", "b", "c"}
+	ExpectThat(t, []string{"c", "a", "b"}, UnorderedElementsAreSlice(want))
+	ExpectThat(t, []int{-1, 5}, UnorderedElementsAreSlice([]Matcher{Gt(1), Lt(0)}))
+	ExpectThat(t, []string{"a", "b"}, Not(UnorderedElementsAreSlice(want)))
+}
+
 func TestContains(t *testing.T) {
 	// Empty matcher list
 	ExpectThat(t, []string{}, Contains())
@@ -247,6 +275,82 @@ type TestStruct struct {
 	Value int
 }
 
+func TestContains_MapsAndChannels(t *testing.T) {
+	m := map[string]int{"a": 1, "bb": 2, "c": 3}
+	ExpectThat(t, m, Contains(1, Gt(2)))
+	ExpectThat(t, m, ElementsAreUnordered(3, 2, 1))
+	ExpectThat(t, m, SubsetOf(1, 2, 3, 4))
+	ExpectThat(t, m, Not(Contains(4)))
+	ExpectThat(t, m, Contains(KeyVal(Len(2), Gt(1)), MapEntries()))
+	ExpectThat(t, m, ElementsAreUnordered(MapEntries(), KeyVal("a", 1), KeyVal("bb", 2), KeyVal("c", Any())))
+	ExpectThat(t, m, Not(Contains(KeyVal("a", 2), MapEntries())))
+
+	ch := make(chan string, 3)
+	ch <- "x"
+	ch <- "y"
+	ExpectThat(t, ch, Contains("y"))
+	ExpectThat(t, ch, ElementsAreUnordered("y", "x"))
+	// The values are still there.
+	ExpectThat(t, ch, Receives("x"))
+	ExpectThat(t, ch, Each("y"))
+	// Channels whose values couldn't be put back aren't iterable, and are
+	// left as they were.
+	close(ch)
+	ExpectThat(t, ch, Not(Contains("y")))
+	ExpectThat(t, ch, Not(Each(Any())))
+	ExpectThat(t, ch, Receives("y"))
+	ExpectThat(t, ch, Each(Any()))
+	ExpectThat(t, make(chan<- string, 1), Not(Contains(Any())))
+	ExpectThat(t, make(chan string), Not(Contains(Any())))
+	full := make(chan string, 2)
+	full <- "x"
+	full <- "y"
+	ExpectThat(t, full, ElementsAreUnordered("x", "y"))
+	ExpectThat(t, full, Contains("y"))
+	ExpectThat(t, full, Len(2))
+	ExpectThat(t, full, Receives("x"))
+	ExpectThat(t, full, Receives("y"))
+
+	r := &testReporter{}
+	ExpectThat(r, map[string]int{"a": 1, "b": 2}, ElementsAreUnordered(1, 3))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has elements matching (in any order) [is equal to 1 (int); is equal to 3 (int)]",
+		"  Got: map[a:1 b:2] (map[string]int)",
+		"  ...where matcher 1 matches no elements (wanted is equal to 3 (int)); value at key b matches no matchers",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{"a": 1}, ElementsAreUnordered(KeyVal("b", 1), MapEntries()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("; entry at key a matches no matchers"))
+
+	r.Reset()
+	ch = make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	ch <- "bb"
+	ExpectThat(r, ch, ElementsAreUnordered("a", StartsWith("a"), StartsWith("b")))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no permutation could pair all matchers and values, "+
+		"closest match is 2/3 with received value 0 -> matcher "))
+
+	r.Reset()
+	ExpectThat(r, make(chan<- string, 1), Contains(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type chan<- string isn't iterable"))
+
+	r.Reset()
+	recvOnly := make(chan int, 3)
+	recvOnly <- 1
+	recvOnly <- 2
+	ExpectThat(r, (<-chan int)(recvOnly), Contains(5))
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("...where type <-chan int isn't iterable: "+
+		"only channels that can be both received from and sent to are, so that their values can be put back")))
+	ExpectEq(t, len(recvOnly), 2)
+
+	r.Reset()
+	ExpectThat(r, make(chan int), Each(Any()))
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("...where type chan int isn't iterable: the channel is unbuffered")))
+}
+
 func TestMapIs(t *testing.T) {
 	// Empty map
 	ExpectThat(t, map[string]int{}, MapIs(map[string]int{}))
@@ -637,3 +741,670 @@ func TestMapContainsKVs(t *testing.T) {
 		"  Got: map[a:1 b:2] (map[string]int)",
 	))
 }
+
+func TestKeys(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	ExpectThat(t, m, Keys(ElementsAreUnordered("c", "a", "b")))
+	ExpectThat(t, m, Keys([]string{"a", "b", "c"}))
+	ExpectThat(t, m, Keys(ElementsAre("a", "b", "c")))
+	ExpectThat(t, m, Keys(Contains(StartsWith("a"))))
+	ExpectThat(t, m, Keys(Len(3)))
+	ExpectThat(t, m, Not(Keys(Contains("d"))))
+	ExpectThat(t, map[int]bool{3: true, 1: false, 2: true}, Keys([]int{1, 2, 3}))
+	ExpectThat(t, map[string]int{}, Keys(Empty()))
+	ExpectThat(t, []string{"a"}, Not(Keys(Any())))
+
+	r := &testReporter{}
+	ExpectThat(r, m, Keys(Contains("d")))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has keys which contains elements matching [is equal to d (string)]",
+		"  Got: map[a:1 b:2 c:3] (map[string]int)",
+		"  ...where keys are [a b c] ([]string), "+
+			"where matcher 0 matches no elements (wanted is equal to d (string))",
+	))
+
+	r.Reset()
+	ExpectThat(r, []string{"a"}, Keys(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type []string isn't a map"))
+}
+
+func TestValues(t *testing.T) {
+	m := map[string]int{"a": 3, "b": 2, "c": 1}
+	ExpectThat(t, m, Values(ElementsAreUnordered(1, 2, 3)))
+	ExpectThat(t, m, Values([]int{3, 2, 1})) // ordered by key
+	ExpectThat(t, m, Values(Contains(Gt(2))))
+	ExpectThat(t, m, Not(Values(Contains(Gt(3)))))
+	ExpectThat(t, map[string]int{}, Values(Empty()))
+	ExpectThat(t, "abc", Not(Values(Any())))
+
+	r := &testReporter{}
+	ExpectThat(r, m, Values(Len(2)))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has values which has length which is equal to 2 (int)",
+		"  Got: map[a:3 b:2 c:1] (map[string]int)",
+		"  ...where values are [3 2 1] ([]int), where length is 3",
+	))
+}
+
+func TestMapKeysAndValues(t *testing.T) {
+	m := map[string]int{"user:a": 3, "user:b": 1, "user:c": 2}
+	ExpectThat(t, m, MapKeys(Each(StartsWith("user:"))))
+	ExpectThat(t, m, MapKeys(Contains("user:b")))
+	ExpectThat(t, m, Not(MapKeys(Contains("user:d"))))
+	ExpectThat(t, m, MapValues(Each(Gt(0))))
+	ExpectThat(t, m, MapValues(HasNoDuplicates()))
+	ExpectThat(t, m, Not(MapValues(IsSorted())))
+
+	r := &testReporter{}
+	ExpectThat(r, m, MapValues(Each(Gt(1))))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has values which each element is greater than 1 (int)",
+		"  Got: map[user:a:3 user:b:1 user:c:2] (map[string]int)",
+		"  ...where values are [3 1 2] ([]int), where element 1 is 1 (int)",
+	}, "\n"))
+}
+
+func TestHasKey(t *testing.T) {
+	m := map[string]int{"alpha": 1, "beta": 2}
+	ExpectThat(t, m, HasKey("alpha"))
+	ExpectThat(t, m, HasKey(StartsWith("b")))
+	ExpectThat(t, map[int]string{3: "c"}, HasKey(Gt(2)))
+	ExpectThat(t, m, Not(HasKey("gamma")))
+	ExpectThat(t, map[string]int{}, Not(HasKey(Any())))
+	ExpectThat(t, []string{"alpha"}, Not(HasKey("alpha")))
+
+	r := &testReporter{}
+	ExpectThat(r, m, HasKey(StartsWith("g")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has a key which starts with 'g'",
+		"  Got: map[alpha:1 beta:2] (map[string]int)",
+		"  ...where no key starts with 'g' (keys are [alpha beta])",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, []string{"alpha"}, HasKey("alpha"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type []string isn't a map"))
+}
+
+func TestHasKeys(t *testing.T) {
+	m := map[string]int{"alpha": 1, "beta": 2, "gamma": 3}
+	ExpectThat(t, m, HasKeys("alpha", "beta"))
+	ExpectThat(t, m, HasKeys("gamma", HasSubstr("et")))
+	ExpectThat(t, m, HasKeys(HasSubstr("a"), HasSubstr("al")))
+	ExpectThat(t, m, HasKeys())
+	ExpectThat(t, m, Not(HasKeys("alpha", "delta")))
+
+	r := &testReporter{}
+	ExpectThat(r, m, HasKeys("alpha", "delta", StartsWith("e")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has keys matching [is equal to alpha (string); is equal to delta (string); starts with 'e']",
+		"  Got: map[alpha:1 beta:2 gamma:3] (map[string]int)",
+		"  ...where no key is equal to delta (string); no key starts with 'e' (keys are [alpha beta gamma])",
+	}, "\n"))
+}
+
+func TestHasValue(t *testing.T) {
+	m := map[string]int{"alpha": 1, "beta": 20}
+	ExpectThat(t, m, HasValue(20))
+	ExpectThat(t, m, HasValue(Gt(10)))
+	ExpectThat(t, map[int][]string{1: {"x"}}, HasValue(Contains("x")))
+	ExpectThat(t, m, Not(HasValue(Lt(0))))
+	ExpectThat(t, map[string]int{}, Not(HasValue(Any())))
+	ExpectThat(t, []int{20}, Not(HasValue(20)))
+
+	r := &testReporter{}
+	ExpectThat(r, map[string]string{"a": "foo", "b": "bar"}, HasValue(HasSubstr("baz")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has a value which has substring 'baz'",
+		"  Got: map[a:foo b:bar] (map[string]string)",
+		"  ...where no value has substring 'baz' (value at key a is foo (string); value at key b is bar (string))",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, map[string][]int{"a": {1}, "b": {1, 2}}, HasValue(Len(3)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no value has length which is equal to 3 (int) ("+
+		"value at key a is [1] ([]int), where length is 1; "+
+		"value at key b is [1 2] ([]int), where length is 2)"))
+
+	r.Reset()
+	ExpectThat(r, map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}, HasValue(Gt(5)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no value is greater than 5 (int) ("+
+		"value at key 1 is 1 (int); value at key 2 is 2 (int); value at key 3 is 3 (int); and 2 more)"))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{}, HasValue(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where map is empty"))
+
+	r.Reset()
+	ExpectThat(r, []int{20}, HasValue(20))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type []int isn't a map"))
+}
+
+func TestContainsValues(t *testing.T) {
+	m := map[string]int{"alpha": 1, "beta": 20, "gamma": 300}
+	ExpectThat(t, m, ContainsValues(1, 20))
+	ExpectThat(t, m, ContainsValues(Gt(100), Lt(10)))
+	ExpectThat(t, m, ContainsValues(Gt(0), Gt(1)))
+	ExpectThat(t, m, ContainsValues())
+	ExpectThat(t, m, Not(ContainsValues(1, 2)))
+
+	r := &testReporter{}
+	ExpectThat(r, map[string]int{"a": 1, "b": 2}, ContainsValues(1, Lt(0), Gt(5)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has values matching [is equal to 1 (int); is less than 0 (int); is greater than 5 (int)]",
+		"  Got: map[a:1 b:2] (map[string]int)",
+		"  ...where no value is less than 0 (int) (value at key a is 1 (int); value at key b is 2 (int)); " +
+			"no value is greater than 5 (int) (value at key a is 1 (int); value at key b is 2 (int))",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{"a": 1, "b": 2}, ContainsValues(3))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no value is equal to 3 (int) (value at key a is 1 (int), where "))
+}
+
+func TestIsSorted(t *testing.T) {
+	ExpectThat(t, []int{1, 2, 2, 5}, IsSorted())
+	ExpectThat(t, [3]float64{-1.5, 0, 2}, IsSorted())
+	ExpectThat(t, []string{"apple", "banana", "cherry"}, IsSorted())
+	ExpectThat(t, []uint8{}, IsSorted())
+	ExpectThat(t, []Celsius{-40, 0, 100}, IsSorted())
+	ExpectThat(t, []any{1, 2, 3}, IsSorted())
+	ExpectThat(t, []*big.Int{big.NewInt(1), big.NewInt(10)}, IsSorted())
+	now := time.Now()
+	ExpectThat(t, []time.Time{now, now.Add(time.Second)}, IsSorted())
+
+	ExpectThat(t, []int{3, 1, 2}, Not(IsSorted()))
+	ExpectThat(t, []time.Time{now, now.Add(-time.Second)}, Not(IsSorted()))
+	ExpectThat(t, []any{1, "a"}, Not(IsSorted()))
+	ExpectThat(t, []bool{false, true}, Not(IsSorted()))
+	ExpectThat(t, 12, Not(IsSorted()))
+
+	r := &testReporter{}
+	ExpectThat(r, []int{1, 2, 5, 3, 4}, IsSorted())
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: is sorted in ascending order",
+		"  Got: [1 2 5 3 4] ([]int)",
+		"  ...where elements 2 and 3 are out of order (5, then 3)",
+	))
+
+	r.Reset()
+	ExpectThat(r, []any{1, "a"}, IsSorted())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where elements 0 and 1 can't be compared: types int and string can't be compared"))
+
+	r.Reset()
+	ExpectThat(r, []bool{false, true}, IsSorted())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where elements 0 and 1 can't be compared: type bool isn't ordered"))
+
+	r.Reset()
+	ExpectThat(r, 12, IsSorted())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestSortedBy(t *testing.T) {
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+	ExpectThat(t, []string{"b", "aa", "cc", "ddd"}, SortedBy(byLen))
+	ExpectThat(t, []int{3, 2, 2, 1}, SortedBy(func(a, b int) bool { return a > b }))
+	ExpectThat(t, []any{"x", "yy"}, SortedBy(byLen))
+	ExpectThat(t, []string{"ccc", "a"}, Not(SortedBy(byLen)))
+	ExpectThat(t, []int{1, 2}, Not(SortedBy(byLen)))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"a", "ccc", "bb"}, SortedBy(byLen))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: is sorted by the given ordering",
+		"  Got: [a ccc bb] ([]string)",
+		"  ...where elements 1 and 2 are out of order (ccc, then bb)",
+	))
+
+	r.Reset()
+	ExpectThat(r, []int{1, 2}, SortedBy(byLen))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where elements 0 and 1 can't be compared: element is of type int, not string"))
+}
+
+func TestEach(t *testing.T) {
+	ExpectThat(t, []int{1, 2, 3}, Each(Gt(0)))
+	ExpectThat(t, [2]string{"ab", "ac"}, Each(StartsWith("a")))
+	ExpectThat(t, map[string]int{"a": 1, "b": 2}, Each(Lt(3)))
+	ExpectThat(t, []int{}, Each(Gt(0)))
+	ExpectThat(t, [][]int{{1}, {2}}, Each(Len(1)))
+	ExpectThat(t, []User{{"Alice", "a@x.com"}, {"Bob", "b@x.com"}}, Each(Field("Email", HasSubstr("@x.com"))))
+
+	ExpectThat(t, []int{1, -2, 3}, Not(Each(Gt(0))))
+	ExpectThat(t, map[string]int{"a": 1, "b": 5}, Not(Each(Lt(3))))
+	ExpectThat(t, 12, Not(Each(Any())))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"a", "bb", "c", "ddd"}, Each(Len(1)))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: each element has length which is equal to 1 (int)",
+		"  Got: [a bb c ddd] ([]string)",
+		"  ...where element 1 is bb (string), where length is 2; "+
+			"element 3 is ddd (string), where length is 3",
+	))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{"a": 1, "b": 5, "c": 7}, Each(Lt(3)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(
+		"...where value at key b is 5 (int); value at key c is 7 (int)"))
+
+	r.Reset()
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 4
+	ExpectThat(r, ch, Each(Lt(3)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where received value 1 is 4 (int)"))
+
+	r.Reset()
+	ExpectThat(r, 12, Each(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestContainsN(t *testing.T) {
+	ExpectThat(t, []int{5, 11, 12, 20}, ContainsN(Gt(10), 3))
+	ExpectThat(t, []int{5, 11, 12, 20}, ContainsN(Gt(10), Ge(1)))
+	ExpectThat(t, []string{"INFO a", "ERROR b"}, ContainsN(HasSubstr("ERROR"), 1))
+	ExpectThat(t, []string{"INFO a", "ERROR b"}, ContainsN(HasSubstr("PANIC"), 0))
+	ExpectThat(t, map[string]int{"a": 1, "b": 2, "c": 3}, ContainsN(Ge(2), 2))
+	ExpectThat(t, []int{}, ContainsN(Any(), 0))
+
+	ExpectThat(t, []int{5, 11, 12, 20}, Not(ContainsN(Gt(10), 2)))
+	ExpectThat(t, 12, Not(ContainsN(Any(), 0)))
+
+	r := &testReporter{}
+	ExpectThat(r, []int{5, 11, 12, 20}, ContainsN(Gt(10), Lt(3)))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has a number of elements which is greater than 10 (int) that is less than 3 (int)",
+		"  Got: [5 11 12 20] ([]int)",
+		"  ...where 3 elements matched (1, 2, 3)",
+	))
+
+	r.Reset()
+	ExpectThat(r, []int{5, 11}, ContainsN(Gt(10), 2))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where element 1 matched"))
+
+	r.Reset()
+	ExpectThat(r, []int{5}, ContainsN(Gt(10), 1))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no elements matched"))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{"a": 1, "b": 2, "c": 3}, ContainsN(Ge(2), 1))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where 2 values matched (keys b, c)"))
+
+	r.Reset()
+	ExpectThat(r, 12, ContainsN(Any(), 0))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestSubsetOf(t *testing.T) {
+	ExpectThat(t, []string{"read", "write"}, SubsetOf("read", "write", "admin"))
+	ExpectThat(t, []string{"write", "read"}, SubsetOf("read", "write"))
+	ExpectThat(t, []int{1, 5}, SubsetOf(1, Gt(3), Lt(0)))
+	ExpectThat(t, []string{}, SubsetOf("a"))
+	ExpectThat(t, []string{}, SubsetOf())
+	ExpectThat(t, []string{"a", "a"}, SubsetOf("a", "a", "b"))
+
+	ExpectThat(t, []string{"a", "a"}, Not(SubsetOf("a", "b")))
+	ExpectThat(t, []string{"read", "delete"}, Not(SubsetOf("read", "write")))
+	ExpectThat(t, []string{"a", "b", "c"}, Not(SubsetOf("a", "b")))
+	ExpectThat(t, 12, Not(SubsetOf(12)))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"read", "delete", "sudo"}, SubsetOf("read", "write", "admin"))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has elements each matching a different one of ["+
+			"is equal to read (string); is equal to write (string); is equal to admin (string)]",
+		"  Got: [read delete sudo] ([]string)",
+		"  ...where value 1 matches no matchers; value 2 matches no matchers",
+	))
+
+	r.Reset()
+	ExpectThat(r, []string{"a", "a"}, SubsetOf("a", "b"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(
+		"...where no permutation could match all values, closest match is 1/2 with value 0 -> matcher 0"))
+
+	r.Reset()
+	ExpectThat(r, []string{"a", "b", "c"}, SubsetOf("a", "b"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where at most 2 elements expected but got 3"))
+}
+
+func TestContains_ConflictingMatchers(t *testing.T) {
+	// Both matchers want the last element, which requires backtracking past
+	// the end of the list of matchers.
+	ExpectThat(t, []string{"p", "q", "r"}, Not(Contains("r", "r")))
+	ExpectThat(t, []string{"p", "q", "r", "r"}, Contains("r", "r"))
+}
+
+func TestSupersetOf(t *testing.T) {
+	ExpectThat(t, []string{"a", "b", "c"}, SupersetOf("c", "a"))
+	ExpectThat(t, []string{"a", "b", "a"}, SupersetOf("a", "a"))
+	ExpectThat(t, []string{"a", "b"}, SupersetOf())
+	ExpectThat(t, []string{"a", "b"}, Not(SupersetOf("a", "a")))
+	ExpectThat(t, []string{"a", "b"}, Not(SupersetOf("d")))
+
+	// Set semantics
+	ExpectThat(t, []string{"a", "b"}, SupersetOf("a", "a", IgnoreMultiplicity()))
+	ExpectThat(t, []int{5}, SupersetOf(Gt(1), Gt(2), IgnoreMultiplicity()))
+	ExpectThat(t, []int{5}, Not(SupersetOf(Gt(1), Gt(2))))
+	ExpectThat(t, []int{5}, Not(SupersetOf(Gt(1), Gt(6), IgnoreMultiplicity())))
+	ExpectThat(t, []int{}, Not(SupersetOf(1, IgnoreMultiplicity())))
+	ExpectThat(t, 12, Not(SupersetOf(IgnoreMultiplicity())))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"a", "b"}, SupersetOf("a", "a"))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: contains elements matching [is equal to a (string); is equal to a (string)]",
+		"  Got: [a b] ([]string)",
+		"  ...where no permutation could satisfy all matchers, closest match is 1/2 with value 0 -> matcher 0",
+	))
+
+	r.Reset()
+	ExpectThat(r, []int{5, 6}, SupersetOf(Gt(1), Gt(6), IgnoreMultiplicity()))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: contains elements (not necessarily distinct) matching ["+
+			"is greater than 1 (int); is greater than 6 (int)]",
+		"  Got: [5 6] ([]int)",
+		"  ...where matcher 1 matches no elements (wanted is greater than 6 (int))",
+	))
+}
+
+func TestIgnoreMultiplicity_SubsetOfAndElementsAreUnordered(t *testing.T) {
+	ExpectThat(t, []string{"a", "a"}, SubsetOf("a", IgnoreMultiplicity()))
+	ExpectThat(t, []string{"a", "a"}, Not(SubsetOf("a")))
+	ExpectThat(t, []int{5, 6, 7}, SubsetOf(Gt(4), Lt(0), IgnoreMultiplicity()))
+	ExpectThat(t, []string{}, SubsetOf("a", IgnoreMultiplicity()))
+	ExpectThat(t, []string{"a", "c"}, Not(SubsetOf("a", "b", IgnoreMultiplicity())))
+
+	ExpectThat(t, []string{"a", "b", "a"}, ElementsAreUnordered("b", "a", IgnoreMultiplicity()))
+	ExpectThat(t, []string{"a", "b"}, ElementsAreUnordered("b", "a", "a", IgnoreMultiplicity()))
+	ExpectThat(t, []string{"a", "a"}, Not(ElementsAreUnordered("b", "a", IgnoreMultiplicity())))
+	ExpectThat(t, []string{"a", "b"}, Not(ElementsAreUnordered("a", IgnoreMultiplicity())))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"a", "c", "a"}, SubsetOf("a", "b", IgnoreMultiplicity()))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has elements each matching one of [is equal to a (string); is equal to b (string)]",
+		"  Got: [a c a] ([]string)",
+		"  ...where value 1 matches no matchers",
+	))
+}
+
+func TestContainsSubsequence(t *testing.T) {
+	events := []string{"start", "read", "commit", "read", "stop"}
+	ExpectThat(t, events, ContainsSubsequence("start", "commit", "stop"))
+	ExpectThat(t, events, ContainsSubsequence("read", "read"))
+	ExpectThat(t, events, ContainsSubsequence("start", "read", "commit", "read", "stop"))
+	ExpectThat(t, events, ContainsSubsequence())
+	ExpectThat(t, []int{1, 5, 2, 6}, ContainsSubsequence(1, 2, Gt(5)))
+	ExpectThat(t, [3]int{1, 2, 3}, ContainsSubsequence(1, 3))
+
+	ExpectThat(t, []int{1, 2, 3}, Not(ContainsSubsequence(3, 1)))
+	ExpectThat(t, events, Not(ContainsSubsequence("commit", "start")))
+	ExpectThat(t, events, Not(ContainsSubsequence("stop", "stop")))
+	ExpectThat(t, []int{}, Not(ContainsSubsequence(1)))
+	ExpectThat(t, 12, Not(ContainsSubsequence()))
+
+	r := &testReporter{}
+	ExpectThat(r, events, ContainsSubsequence("start", "commit", "start"))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: contains a subsequence matching ["+
+			"is equal to start (string); is equal to commit (string); is equal to start (string)]",
+		"  Got: [start read commit read stop] ([]string)",
+		"  ...where matcher 2 matches no elements after element 2, "+
+			"where matcher 1 matched (wanted is equal to start (string))",
+	))
+
+	r.Reset()
+	ExpectThat(r, events, ContainsSubsequence("rollback"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(
+		"...where matcher 0 matches no elements (wanted is equal to rollback (string))"))
+
+	r.Reset()
+	ExpectThat(r, 12, ContainsSubsequence())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestContainsConsecutive(t *testing.T) {
+	frames := []string{"SYN", "SYN-ACK", "ACK", "DATA", "FIN"}
+	ExpectThat(t, frames, ContainsConsecutive("SYN", "SYN-ACK", "ACK"))
+	ExpectThat(t, frames, ContainsConsecutive("DATA", "FIN"))
+	ExpectThat(t, frames, ContainsConsecutive())
+	ExpectThat(t, []int{1, 2, 3, 4}, ContainsConsecutive(2, Gt(2)))
+	ExpectThat(t, [4]int{1, 2, 1, 3}, ContainsConsecutive(1, 3))
+
+	ExpectThat(t, []int{1, 2, 3, 4}, Not(ContainsConsecutive(1, 3)))
+	ExpectThat(t, []int{1, 2}, Not(ContainsConsecutive(1, 2, 3)))
+	ExpectThat(t, 12, Not(ContainsConsecutive()))
+
+	r := &testReporter{}
+	ExpectThat(r, frames, ContainsConsecutive("ACK", HasSubstr("DAT"), "RST"))
+	ExpectThat(t, r.nonFatals[0], StartsWith(strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: contains a consecutive run matching [" +
+			"is equal to ACK (string); has substring 'DAT'; is equal to RST (string)]",
+		"  Got: [SYN SYN-ACK ACK DATA FIN] ([]string)",
+		"  ...where closest run starts at element 2 and satisfies 2/3 matchers, but element 4: ",
+	}, "\n")))
+
+	r.Reset()
+	ExpectThat(r, []int{1, 2}, ContainsConsecutive(1, 2, 3))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where at least 3 elements expected but got 2"))
+
+	r.Reset()
+	ExpectThat(r, 12, ContainsConsecutive())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestSliceStartsWith(t *testing.T) {
+	ExpectThat(t, []string{"git", "commit", "-m"}, SliceStartsWith("git", "commit"))
+	ExpectThat(t, []int{1, 2, 3}, SliceStartsWith(1, Lt(5)))
+	ExpectThat(t, []int{1, 2, 3}, SliceStartsWith(1, 2, 3))
+	ExpectThat(t, []int{}, SliceStartsWith())
+	ExpectThat(t, [2]int{1, 2}, SliceStartsWith(1))
+
+	ExpectThat(t, []int{1}, Not(SliceStartsWith(1, 2)))
+	ExpectThat(t, []int{1, 2, 3}, Not(SliceStartsWith(2)))
+	ExpectThat(t, 12, Not(SliceStartsWith()))
+
+	r := &testReporter{}
+	ExpectThat(r, []int{1, 2, 3}, SliceStartsWith(Gt(1), Lt(5), 4))
+	ExpectThat(t, r.nonFatals[0], StartsWith(strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: starts with elements matching [" +
+			"is greater than 1 (int); is less than 5 (int); is equal to 4 (int)]",
+		"  Got: [1 2 3] ([]int)",
+		"  ...where element 0: doesn't match; element 2: ",
+	}, "\n")))
+
+	r.Reset()
+	ExpectThat(r, []int{1}, SliceStartsWith(1, 2))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where at least 2 elements expected but got 1"))
+
+	r.Reset()
+	ExpectThat(r, 12, SliceStartsWith())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestSliceEndsWith(t *testing.T) {
+	ExpectThat(t, []string{"etc", "config", "app.yaml"}, SliceEndsWith("config", "app.yaml"))
+	ExpectThat(t, []int{1, 2, 3}, SliceEndsWith(Gt(1), 3))
+	ExpectThat(t, []int{1, 2, 3}, SliceEndsWith(1, 2, 3))
+	ExpectThat(t, []int{}, SliceEndsWith())
+
+	ExpectThat(t, []int{1, 2, 3}, Not(SliceEndsWith(2)))
+	ExpectThat(t, []int{3}, Not(SliceEndsWith(2, 3)))
+
+	r := &testReporter{}
+	ExpectThat(r, []int{1, 2, 3}, SliceEndsWith(Lt(2), Lt(3)))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: ends with elements matching [is less than 2 (int); is less than 3 (int)]",
+		"  Got: [1 2 3] ([]int)",
+		"  ...where element 1: doesn't match; element 2: doesn't match",
+	))
+}
+
+func TestHasNoDuplicates(t *testing.T) {
+	ExpectThat(t, []int{1, 2, 3}, HasNoDuplicates())
+	ExpectThat(t, []string{}, HasNoDuplicates())
+	ExpectThat(t, [2]User{{"a", "x"}, {"a", "y"}}, HasNoDuplicates())
+	ExpectThat(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, HasNoDuplicates())
+
+	ExpectThat(t, []string{"a", "b", "a"}, Not(HasNoDuplicates()))
+	ExpectThat(t, []*big.Int{big.NewInt(1), big.NewInt(1)}, Not(HasNoDuplicates()))
+	ExpectThat(t, []User{{"a", "x"}, {"a", "x"}}, Not(HasNoDuplicates()))
+	ExpectThat(t, 12, Not(HasNoDuplicates()))
+
+	r := &testReporter{}
+	ExpectThat(r, []string{"a", "b", "a", "c", "b", "a"}, HasNoDuplicates())
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has no duplicate elements",
+		"  Got: [a b a c b a] ([]string)",
+		"  ...where a appears at elements 0, 2, 5; b appears at elements 1, 4",
+	))
+
+	r.Reset()
+	ExpectThat(r, 12, HasNoDuplicates())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int isn't iterable"))
+}
+
+func TestPointwise(t *testing.T) {
+	near := func(want float64) Matcher { return Near(want, 0.001) }
+	ExpectThat(t, []float64{0.5001, 1.25, 3}, Pointwise(near, []float64{0.5, 1.25, 3}))
+	ExpectThat(t, []string{"Alice", "BOB"}, Pointwise(EqualIgnoringCase, []string{"alice", "bob"}))
+	ExpectThat(t, []int{}, Pointwise(near, nil))
+	ExpectThat(t, []any{1, "a"}, Pointwise(func(e any) Matcher { return Eq(e) }, []any{1, "a"}))
+
+	ExpectThat(t, []float64{0.6, 1.25}, Not(Pointwise(near, []float64{0.5, 1.25})))
+	ExpectThat(t, []float64{0.5}, Not(Pointwise(near, []float64{0.5, 1.25})))
+	ExpectThat(t, 12, Not(Pointwise(near, []float64{12})))
+
+	r := &testReporter{}
+	ExpectThat(r, []float64{0.6, 1.25, 2}, Pointwise(near, []float64{0.5, 1.25, 3}))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: has elements matching ["+
+			"is within 0.001 of 0.5; is within 0.001 of 1.25; is within 0.001 of 3]",
+		"  Got: [0.6 1.25 2] ([]float64)",
+		"  ...where element 0: difference is 0.09999999999999998; element 2: difference is 1",
+	))
+}
+
+func TestFlattened(t *testing.T) {
+	pages := [][]string{{"a", "b"}, {"c"}}
+	ExpectThat(t, pages, Flattened(ElementsAre("a", "b", "c")))
+	ExpectThat(t, pages, Flattened([]string{"a", "b", "c"}))
+	ExpectThat(t, [][]int{{1}, {2, 3}}, Flattened(Each(Gt(0))))
+	ExpectThat(t, [2][]int{{1}, {}}, Flattened([]int{1}))
+	ExpectThat(t, [][]int{}, Flattened(Empty()))
+	ExpectThat(t, [][][]int{{{1}, {2}}, {{3}}}, Flattened([][]int{{1}, {2}, {3}}))
+	ExpectThat(t, []any{1, []string{"a"}, []any{2, []int{3}}}, Flattened(ElementsAre(1, "a", 2, []int{3})))
+	ExpectThat(t, pages, Not(Flattened(Len(2))))
+	ExpectThat(t, "abc", Not(Flattened(Any())))
+
+	r := &testReporter{}
+	ExpectThat(r, pages, Flattened(Len(2)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when flattened, has length which is equal to 2 (int)",
+		"  Got: [[a b] [c]] ([][]string)",
+		"  ...where flattened value is [a b c] ([]string), where length is 3",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, "abc", Flattened(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type string isn't a slice or array"))
+}
+
+func TestFlattenedRecursively(t *testing.T) {
+	ExpectThat(t, [][][]int{{{1}, {2}}, {{3}}}, FlattenedRecursively([]int{1, 2, 3}))
+	ExpectThat(t, []any{1, []any{2, []int{3}}}, FlattenedRecursively(ElementsAre(1, 2, 3)))
+	ExpectThat(t, []int{1, 2}, FlattenedRecursively([]int{1, 2}))
+	ExpectThat(t, [][]int{{1}, {2}}, Not(FlattenedRecursively(Contains(3))))
+
+	r := &testReporter{}
+	ExpectThat(r, []any{1, []any{2, []int{3}}}, FlattenedRecursively(Len(2)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when flattened recursively, has length which is equal to 2 (int)",
+		"  Got: [1 [2 [3]]] ([]interface {})",
+		"  ...where flattened value is [1 2 3] ([]interface {}), where length is 3",
+	}, "\n"))
+}
+
+type event struct {
+	Level string
+	Code  int
+}
+
+func TestGroupedBy(t *testing.T) {
+	level := func(e event) string { return e.Level }
+	events := []event{{"error", 502}, {"warn", 0}, {"info", 0}, {"error", 503}, {"warn", 0}}
+	ExpectThat(t, events, GroupedBy(level, map[string]any{
+		"error": Each(Field("Code", Gt(500))),
+		"warn":  Len(2),
+		"fatal": Empty(),
+	}))
+	ExpectThat(t, []int{1, 2, 3, 4}, GroupedBy(func(i int) bool { return i%2 == 0 }, map[bool]any{
+		true:  []int{2, 4},
+		false: []int{1, 3},
+	}))
+	ExpectThat(t, []any{"a", 1, "bb"}, GroupedBy(func(x any) any { return fmt.Sprintf("%T", x) }, map[any]any{
+		"string": Len(2),
+	}))
+	ExpectThat(t, events, Not(GroupedBy(level, map[string]any{"warn": Len(3)})))
+	ExpectThat(t, []any{event{}, 1}, Not(GroupedBy(level, map[string]any{})))
+	ExpectThat(t, "abc", Not(GroupedBy(level, map[string]any{})))
+
+	r := &testReporter{}
+	ExpectThat(r, events, GroupedBy(level, map[string]any{
+		"error": Each(Field("Code", Gt(502))),
+		"info":  Len(1),
+		"warn":  Len(1),
+	}))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when grouped, has [" +
+			"group error each element has field Code which is greater than 502 (int); " +
+			"group info has length which is equal to 1 (int); " +
+			"group warn has length which is equal to 1 (int)]",
+		"  Got: [{error 502} {warn 0} {info 0} {error 503} {warn 0}] ([]gotest.event)",
+		"  ...where group error is [{error 502} {error 503}] ([]gotest.event), " +
+			"where element 0 is {error 502} (gotest.event), where field Code is 502 (int); " +
+			"group warn is [{warn 0} {warn 0}] ([]gotest.event), where length is 2",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, []any{event{}, 1}, GroupedBy(level, map[string]any{}))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where element 1 is of type int, not gotest.event"))
+
+	r.Reset()
+	ExpectThat(r, "abc", GroupedBy(level, map[string]any{}))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type string isn't iterable"))
+}
+
+// The bipartite matching must handle more values than matchers, and vice
+// versa.
+func TestUnorderedMatching_UnequalSizes(t *testing.T) {
+	ExpectThat(t, []int{1, 2, 3, 4}, Contains(4))
+	ExpectThat(t, []int{1, 2, 3, 4}, Contains(Gt(2), 4))
+	ExpectThat(t, []int{1, 2, 3, 4}, Not(Contains(Gt(3), 4)))
+	ExpectThat(t, []int{1, 2}, SubsetOf(Lt(2), 2, 3, 4))
+	ExpectThat(t, []int{1, 2}, Not(SubsetOf(Gt(1), 2, 3, 4)))
+}
@@ -0,0 +1,188 @@
+package gotest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of unchanged lines shown around each change in a line diff.
+const diffContextLines = 2
+
+// Largest table, in cells, that diffLines() builds to find a minimal diff.
+// Past this, it falls back to a diff that's cheap to compute but not minimal.
+const maxDiffCells = 1 << 20
+
+// One line of a line-based diff: ' ' for lines that are unchanged, '-' for
+// lines only in the wanted text, and '+' for lines only in the actual text.
+type diffLine struct {
+	op   byte
+	line int // 1-based; from the wanted text for ' ' and '-', else the actual text.
+	text string
+}
+
+// Computes a minimal line-based diff that transforms `want` into `got`.
+//
+// Finding it takes time and memory proportional to the product of the
+// lengths of the parts that differ, after common prefixes and suffixes. If
+// that's over maxDiffCells, the whole of those parts is shown as removed and
+// then added instead, which still starts at the first difference.
+func diffLines(want, got []string) []diffLine {
+	// Common prefixes and suffixes are cheap to find and keep the quadratic
+	// part below small for the usual case of a few localized changes.
+	prefix := 0
+	for prefix < len(want) && prefix < len(got) && want[prefix] == got[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(want)-prefix && suffix < len(got)-prefix &&
+		want[len(want)-1-suffix] == got[len(got)-1-suffix] {
+		suffix++
+	}
+	w, g := want[prefix:len(want)-suffix], got[prefix:len(got)-suffix]
+
+	var result []diffLine
+	for i := 0; i < prefix; i++ {
+		result = append(result, diffLine{' ', i + 1, want[i]})
+	}
+	if (len(w)+1)*(len(g)+1) > maxDiffCells {
+		for i := range w {
+			result = append(result, diffLine{'-', prefix + i + 1, w[i]})
+		}
+		for j := range g {
+			result = append(result, diffLine{'+', prefix + j + 1, g[j]})
+		}
+	} else {
+		result = append(result, diffLCS(w, g, prefix)...)
+	}
+	for k := len(want) - suffix; k < len(want); k++ {
+		result = append(result, diffLine{' ', k + 1, want[k]})
+	}
+	return result
+}
+
+// Computes a minimal diff of `w` and `g` from the table of their longest
+// common subsequences. Line numbers are offset by `prefix`.
+func diffLCS(w, g []string, prefix int) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of w[i:] and g[j:].
+	lcs := make([][]int, len(w)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(g)+1)
+	}
+	for i := len(w) - 1; i >= 0; i-- {
+		for j := len(g) - 1; j >= 0; j-- {
+			if w[i] == g[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []diffLine
+	i, j := 0, 0
+	for i < len(w) || j < len(g) {
+		switch {
+		case i < len(w) && j < len(g) && w[i] == g[j]:
+			result = append(result, diffLine{' ', prefix + i + 1, w[i]})
+			i++
+			j++
+		case j == len(g) || (i < len(w) && lcs[i+1][j] >= lcs[i][j+1]):
+			result = append(result, diffLine{'-', prefix + i + 1, w[i]})
+			i++
+		default:
+			result = append(result, diffLine{'+', prefix + j + 1, g[j]})
+			j++
+		}
+	}
+	return result
+}
+
+// Renders a line diff, showing only the changed lines and a few lines of
+// context around them. Runs of omitted lines are replaced by "...".
+func formatLineDiff(lines []diffLine) string {
+	show := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for k := max(0, i-diffContextLines); k <= min(len(lines)-1, i+diffContextLines); k++ {
+			show[k] = true
+		}
+	}
+
+	var sb strings.Builder
+	skipped := false
+	for i, l := range lines {
+		if !show[i] {
+			skipped = true
+			continue
+		}
+		if skipped && sb.Len() > 0 {
+			sb.WriteString("\n    ...")
+		}
+		skipped = false
+		fmt.Fprintf(&sb, "\n  %c %4d | %s", l.op, l.line, l.text)
+	}
+	if skipped {
+		sb.WriteString("\n    ...")
+	}
+	return sb.String()
+}
+
+// Renders a line diff in the unified format of `diff -u`, where `wantName`
+// and `gotName` label the texts. Only the changed lines and a few lines of
+// context around them are shown, in hunks headed by their line numbers.
+func formatUnifiedDiff(wantName, gotName string, lines []diffLine) string {
+	show := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for k := max(0, i-diffContextLines); k <= min(len(lines)-1, i+diffContextLines); k++ {
+			show[k] = true
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s", wantName, gotName)
+	// The next line number in each text.
+	wantNext, gotNext := 1, 1
+	for start := 0; start < len(lines); {
+		if !show[start] {
+			wantNext++
+			gotNext++
+			start++
+			continue
+		}
+		end := start
+		wantCount, gotCount := 0, 0
+		for ; end < len(lines) && show[end]; end++ {
+			if lines[end].op != '+' {
+				wantCount++
+			}
+			if lines[end].op != '-' {
+				gotCount++
+			}
+		}
+		fmt.Fprintf(&sb, "\n@@ -%s +%s @@", hunkRange(wantNext, wantCount), hunkRange(gotNext, gotCount))
+		for _, l := range lines[start:end] {
+			fmt.Fprintf(&sb, "\n%c%s", l.op, l.text)
+		}
+		wantNext += wantCount
+		gotNext += gotCount
+		start = end
+	}
+	return sb.String()
+}
+
+// Formats the range of lines in a hunk header. As in `diff -u`, an empty
+// range starts at the line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
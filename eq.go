@@ -1,15 +1,25 @@
 package gotest
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
-	"google.golang.org/protobuf/proto"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 // Tests whether x is "equal" to the expected value.
@@ -17,12 +27,22 @@ import (
 // Equality is defined as follows:
 //
 //   - Primitive types (ints, strings, etc) are compared using ==.
-//   - All protos are compared using proto.Equal, including when nested within
-//     other structs, slices, or maps.
+//   - All protos are compared with the semantics of proto.Equal, including when
+//     nested within other structs, slices, or maps. Differences are reported
+//     field-by-field.
 //   - Non-proto structs are compared field-by-field. Unexported fields are
 //     compared only for types that are defined in the same package as the matcher
 //     is used.
-//   - Any type that has a custom Equal method will use that method for comparison.
+//   - Any type that has a custom Equal method will use that method for
+//     comparison. The method may take the type itself, a pointer to it, or an
+//     interface, and may have a pointer receiver even for values that aren't
+//     pointers.
+//   - *big.Int, *big.Float, and *big.Rat are compared numerically, using their
+//     Cmp methods.
+//   - time.Time values are equal if they represent the same instant, regardless
+//     of their locations or monotonic clock readings.
+//   - json.RawMessage values are compared as the JSON they contain, so key
+//     order and whitespace don't matter.
 //
 // This is the default matcher used by all other matchers to compare nested
 // values when passed values directly instead of matchers. If you want to
@@ -40,24 +60,106 @@ import (
 // You can also use ExpectEq() as a shorthand:
 //
 //	ExpectEq(t, 42, 42)
-func Eq(x any) Matcher {
+//
+// EqOptions configure how failures are reported:
+//
+//	ExpectThat(t, deployment, Eq(want, WithDiffStyle(PathDiff)))
+func Eq(x any, opts ...EqOption) Matcher {
 	callerPkg, ok := GetCallerPkg()
 	if !ok {
 		panic("Eq: unable to determine caller package")
 	}
-	opts := []cmp.Option{
+	return newEqMatcher(x, callerPkg, nil, opts)
+}
+
+// Like Eq, but floating-point numbers - including those nested within structs,
+// slices, and maps - are considered equal if they're within `tolerance` of
+// each other.
+//
+// Examples:
+//
+//	type Point struct { X, Y float64 }
+//	ExpectThat(t, Point{0.1 + 0.2, 1}, EqApprox(Point{0.3, 1}, 1e-9))
+//	ExpectThat(t, []float64{1.0, 2.05}, EqApprox([]float64{1, 2}, 0.1))
+func EqApprox(x any, tolerance float64, opts ...EqOption) Matcher {
+	callerPkg, ok := GetCallerPkg()
+	if !ok {
+		panic("EqApprox: unable to determine caller package")
+	}
+	return newEqMatcher(x, callerPkg, []cmp.Option{WithFloatTolerance(tolerance)}, opts)
+}
+
+// Like Eq, but nil slices and maps - including those nested within structs,
+// slices, and maps - are considered equal to empty ones. This is useful for
+// values that have been through serialization, which often doesn't preserve
+// the difference.
+//
+// Examples:
+//
+//	type Config struct { Tags []string }
+//	ExpectThat(t, Config{Tags: []string{}}, EqLoose(Config{}))
+//	ExpectThat(t, map[string]int(nil), EqLoose(map[string]int{}))
+func EqLoose(x any, opts ...EqOption) Matcher {
+	callerPkg, ok := GetCallerPkg()
+	if !ok {
+		panic("EqLoose: unable to determine caller package")
+	}
+	return newEqMatcher(x, callerPkg, []cmp.Option{NilEqualsEmpty()}, opts)
+}
+
+// Like Eq, but numbers of different types are considered equal if they have
+// the same value, using the same type promotion as Gt() and friends. Numbers
+// can only have different types when they're held in interfaces, such as the
+// values of a map[string]any decoded from JSON.
+//
+// Examples:
+//
+//	ExpectThat(t, any(int32(5)), EqNumeric(5))
+//	ExpectThat(t, decoded, EqNumeric(map[string]any{"count": 3, "ratio": 0.5}))
+func EqNumeric(x any, opts ...EqOption) Matcher {
+	callerPkg, ok := GetCallerPkg()
+	if !ok {
+		panic("EqNumeric: unable to determine caller package")
+	}
+	return newEqMatcher(x, callerPkg, []cmp.Option{CompareNumbersAcrossTypes()}, opts)
+}
+
+// Returns the options that Eq() compares with, for use with Equiv(). This
+// allows starting from Eq's behavior and adding to it. As with Eq(),
+// unexported fields are compared only for types defined in the calling
+// package.
+//
+// Example:
+//
+//	ExpectThat(t, got, Equiv(want, DefaultEqOptions(), cmpopts.IgnoreFields(Person{}, "Age")))
+func DefaultEqOptions() cmp.Option {
+	callerPkg, ok := GetCallerPkg()
+	if !ok {
+		panic("DefaultEqOptions: unable to determine caller package")
+	}
+	return cmp.Options(defaultEqOptions(callerPkg))
+}
+
+// Returns the options that Eq() compares with, given the package it's called
+// from and the other types whose unexported fields should be compared.
+func defaultEqOptions(callerPkg string, exposed ...reflect.Type) []cmp.Option {
+	return []cmp.Option{
 		ExportFieldsFrom(callerPkg),
+		exportFieldsOf(exposed),
 		CompareProtos(),
-		IgnoreHiddenFieldsExceptFrom(callerPkg),
+		CompareBigNumbers(),
+		CompareTimes(),
+		CompareRawJSON(),
+		UsePointerEqualMethods(),
+		ignoreHiddenFields(callerPkg, exposed),
 	}
-	return eqMatcher{val: x, opts: opts}
 }
 
 // Like Eq, but allows customizing the comparison behavior using cmp.Options.
 //
 // Note that this matcher does not automatically inject any Export or Ignore
 // options, so using it with types that have unexported fields requires setting
-// some options.
+// some options. DefaultEqOptions() provides the ones that Eq() uses.
 //
 // Examples:
 //
@@ -66,6 +168,7 @@ func Eq(x any) Matcher {
 //	p2 := Person{"Alice", 31}
 //	ExpectThat(t, p1, Equiv(p2, cmpopts.IgnoreFields(Person{}, "Age")))
 //	ExpectThat(t, p1, Not(Equiv(p2)))
+//	ExpectThat(t, p1, Equiv(p2, DefaultEqOptions(), cmpopts.IgnoreFields(Person{}, "Age")))
 func Equiv(x any, options ...cmp.Option) Matcher {
 	return eqMatcher{val: x, opts: options}
 }
@@ -77,27 +180,366 @@ func ExportFieldsFrom(pkg string) cmp.Option {
 }
 
 func IgnoreHiddenFieldsExceptFrom(pkg string) cmp.Option {
-	return cmp.FilterPath(func(p cmp.Path) bool {
-		sf, ok := p.Index(-1).(cmp.StructField)
-		if !ok {
-			return false
-		}
-		r, _ := utf8.DecodeRuneInString(sf.Name())
-		hidden := !unicode.IsUpper(r)
+	return ignoreHiddenFields(pkg, nil)
+}
 
-		return hidden && p.Index(-2).Type().PkgPath() != pkg
+func ignoreHiddenFields(pkg string, exposed []reflect.Type) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return isHiddenField(p, pkg, exposed)
 	}, cmp.Ignore())
 }
 
+// Reports whether `p` leads to an unexported field of a type that's neither
+// from `pkg` nor in `exposed`.
+func isHiddenField(p cmp.Path, pkg string, exposed []reflect.Type) bool {
+	sf, ok := p.Index(-1).(cmp.StructField)
+	if !ok {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(sf.Name())
+	hidden := !unicode.IsUpper(r)
+
+	parent := p.Index(-2).Type()
+	return hidden && parent.PkgPath() != pkg && !slices.Contains(exposed, parent)
+}
+
+func exportFieldsOf(types []reflect.Type) cmp.Option {
+	return cmp.Exporter(func(t reflect.Type) bool {
+		return slices.Contains(types, t)
+	})
+}
+
+// Compares protos field-by-field, with the same semantics as proto.Equal.
+// Unlike comparing them with proto.Equal directly, mismatches are reported
+// with the paths of the fields that differ.
 func CompareProtos() cmp.Option {
-	return cmp.Comparer(func(a, b proto.Message) bool {
-		return proto.Equal(a, b)
+	return protocmp.Transform()
+}
+
+// Compares *big.Int, *big.Float, and *big.Rat values numerically, using their
+// Cmp methods, rather than by their internal representation.
+func CompareBigNumbers() cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *big.Int) bool {
+			if a == nil || b == nil {
+				return a == b
+			}
+			return a.Cmp(b) == 0
+		}),
+		cmp.Comparer(func(a, b *big.Float) bool {
+			if a == nil || b == nil {
+				return a == b
+			}
+			return a.Cmp(b) == 0
+		}),
+		cmp.Comparer(func(a, b *big.Rat) bool {
+			if a == nil || b == nil {
+				return a == b
+			}
+			return a.Cmp(b) == 0
+		}),
+	}
+}
+
+// Compares time.Time values by the instant they represent, like Time.Equal,
+// but ignoring monotonic clock readings. Time.Equal compares the monotonic
+// readings alone when both times have one, so times from different clock
+// sources can differ even if their wall clock times are the same.
+func CompareTimes() cmp.Option {
+	return cmp.Comparer(func(a, b time.Time) bool {
+		return a.Round(0).Equal(b.Round(0))
 	})
 }
 
+// Compares json.RawMessage values by the JSON they contain, rather than byte
+// by byte. Values that aren't valid JSON are compared byte by byte.
+func CompareRawJSON() cmp.Option {
+	rawMessageType := reflect.TypeFor[json.RawMessage]()
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().Type() == rawMessageType
+	}, cmp.Transformer("gotest.ParseJSON", func(m json.RawMessage) any {
+		return parseJSONOrBytes(m)
+	}))
+}
+
+// Compares the strings or byte slices at the given paths as JSON, rather than
+// byte by byte. A path is the names of the struct fields leading to the
+// value, separated by dots, as for SortSlicesAt(). Values that aren't valid
+// JSON are compared byte by byte.
+//
+// Example:
+//
+//	type Event struct { Kind string; Payload []byte }
+//	ExpectThat(t, got, Eq(want, WithCmpOptions(CompareAsJSON("Payload"))))
+func CompareAsJSON(paths ...string) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		if t := p.Last().Type(); t.Kind() != reflect.String && !(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8) {
+			return false
+		}
+		return slices.Contains(paths, p.String())
+	}, cmpopts.AcyclicTransformer("gotest.ParseJSON", func(v any) any {
+		r := reflect.ValueOf(v)
+		if r.Kind() == reflect.String {
+			return parseJSONOrBytes([]byte(r.String()))
+		}
+		return parseJSONOrBytes(r.Bytes())
+	}))
+}
+
+// Parses `data` as JSON if it's valid, or else returns it as a string.
+func parseJSONOrBytes(data []byte) any {
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return string(data)
+	}
+	return parsed
+}
+
+// Compares errors by errors.Is(), in either direction, or failing that by
+// their messages. By default, errors nested in other values are compared like
+// any other values, so errors of types from other packages, whose fields are
+// all unexported, are equal whatever they say.
+//
+// Example:
+//
+//	type Result struct { Value int; Err error }
+//	ExpectThat(t, got, Eq(Result{Err: io.EOF}, WithCmpOptions(EquateErrors())))
+func EquateErrors() cmp.Option {
+	errorType := reflect.TypeFor[error]()
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		t := p.Last().Type()
+		return t != nil && t.Implements(errorType)
+	}, cmp.Comparer(func(x, y any) bool {
+		xErr, yErr := asError(x), asError(y)
+		if xErr == nil || yErr == nil {
+			return xErr == nil && yErr == nil
+		}
+		return errors.Is(xErr, yErr) || errors.Is(yErr, xErr) || xErr.Error() == yErr.Error()
+	}))
+}
+
+// Returns `v` as an error, or nil if it's nil or a nil pointer.
+func asError(v any) error {
+	if v == nil {
+		return nil
+	}
+	if r := reflect.ValueOf(v); r.Kind() == reflect.Pointer && r.IsNil() {
+		return nil
+	}
+	return v.(error)
+}
+
+// Compares opaque values, those of struct types with no exported fields and
+// no Equal method, by what they marshal to with MarshalText() or, failing
+// that, MarshalJSON(). Without this, such values from other packages, such as
+// netip.Addr, are always equal, since Eq() ignores their unexported fields.
+//
+// Example:
+//
+//	ExpectThat(t, got, Eq(want, WithCmpOptions(CompareMarshaled())))
+func CompareMarshaled() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return isOpaqueMarshaler(p.Last().Type())
+	}, cmp.Transformer("gotest.Marshal", marshalOpaque))
+}
+
+// Reports whether `t` is a struct type with no exported fields and no Equal
+// method that can be marshaled to text or JSON.
+func isOpaqueMarshaler(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	pt := reflect.PointerTo(t)
+	if _, ok := pt.MethodByName("Equal"); ok {
+		return false
+	}
+	return pt.Implements(reflect.TypeFor[encoding.TextMarshaler]()) ||
+		pt.Implements(reflect.TypeFor[json.Marshaler]())
+}
+
+// Marshals `v` as chosen by isOpaqueMarshaler(), or describes it and the
+// error if it can't be.
+func marshalOpaque(v any) string {
+	// Through a pointer, so that marshaling methods with pointer receivers can
+	// be called too.
+	ptr := reflect.New(reflect.TypeOf(v))
+	ptr.Elem().Set(reflect.ValueOf(v))
+	var data []byte
+	var err error
+	if m, ok := ptr.Interface().(encoding.TextMarshaler); ok {
+		data, err = m.MarshalText()
+	} else {
+		data, err = ptr.Interface().(json.Marshaler).MarshalJSON()
+	}
+	if err != nil {
+		// Includes the value itself, so that values that both fail to marshal
+		// aren't equal just because of that.
+		return fmt.Sprintf("<can't marshal %#v: %v>", v, err)
+	}
+	return string(data)
+}
+
+// Considers numbers of different types equal if they have the same value, such
+// as int32(5), int64(5), and 5.0.
+func CompareNumbersAcrossTypes() cmp.Option {
+	return cmp.FilterValues(func(x, y any) bool {
+		return reflect.TypeOf(x) != reflect.TypeOf(y) && isNumber(x) && isNumber(y)
+	}, cmp.Comparer(func(x, y any) bool {
+		canCompare, result := tryCompare(x, y)
+		return canCompare && result == 0
+	}))
+}
+
+func isNumber(x any) bool {
+	return isBig(x) || classify(x) != numClassNonNumeric
+}
+
+// Compares values using Equal methods that have pointer receivers, such as
+// `func (*T) Equal(*T) bool` or `func (*T) Equal(T) bool`. cmp only uses
+// Equal methods that can be called on the values as they are, so it ignores
+// these for values that aren't pointers.
+func UsePointerEqualMethods() cmp.Option {
+	return cmp.FilterValues(func(x, y any) bool {
+		t := reflect.TypeOf(x)
+		if t == nil || t != reflect.TypeOf(y) {
+			return false
+		}
+		_, ok := pointerEqualMethod(t)
+		return ok
+	}, cmp.Comparer(func(x, y any) bool {
+		method, _ := pointerEqualMethod(reflect.TypeOf(x))
+		px, py := reflect.New(reflect.TypeOf(x)), reflect.New(reflect.TypeOf(y))
+		px.Elem().Set(reflect.ValueOf(x))
+		py.Elem().Set(reflect.ValueOf(y))
+		arg := py
+		if method.Type.In(1) != py.Type() {
+			arg = py.Elem()
+		}
+		return method.Func.Call([]reflect.Value{px, arg})[0].Bool()
+	}))
+}
+
+// Returns the Equal method of *T, if `t` is a non-pointer type T whose Equal
+// method can only be called through a pointer.
+func pointerEqualMethod(t reflect.Type) (reflect.Method, bool) {
+	if t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface {
+		return reflect.Method{}, false
+	}
+	if _, ok := t.MethodByName("Equal"); ok {
+		return reflect.Method{}, false
+	}
+	pt := reflect.PointerTo(t)
+	method, ok := pt.MethodByName("Equal")
+	if !ok {
+		return reflect.Method{}, false
+	}
+	mt := method.Type
+	if mt.NumIn() != 2 || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	if in := mt.In(1); in != pt && in != t {
+		return reflect.Method{}, false
+	}
+	return method, true
+}
+
+// Considers float32 and float64 values equal if they're within `tolerance` of
+// each other. NaN is never equal to anything.
+//
+// Example:
+//
+//	ExpectThat(t, p1, Equiv(p2, WithFloatTolerance(1e-6)))
+func WithFloatTolerance(tolerance float64) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b float64) bool {
+			return math.Abs(a-b) <= tolerance
+		}),
+		cmp.Comparer(func(a, b float32) bool {
+			return math.Abs(float64(a)-float64(b)) <= tolerance
+		}),
+	}
+}
+
+// Considers nil slices and maps equal to empty, non-nil ones.
+//
+// Example:
+//
+//	ExpectThat(t, []int{}, Equiv([]int(nil), NilEqualsEmpty()))
+func NilEqualsEmpty() cmp.Option {
+	return cmpopts.EquateEmpty()
+}
+
+// Ignores the order of the elements of the slices at `path`, by sorting them
+// with `less` before comparing. The path is the names of the struct fields
+// leading to the slice, separated by dots; slice indices and map keys along
+// the way are omitted, so that "Items.Tags" refers to the Tags of every
+// element of Items.
+//
+// Example:
+//
+//	ExpectThat(t, got, Equiv(want, SortSlicesAt("Spec.Ports", func(a, b Port) bool {
+//		return a.Number < b.Number
+//	})))
+func SortSlicesAt[T any](path string, less func(a, b T) bool) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return p.String() == path
+	}, cmpopts.SortSlices(less))
+}
+
+// Ignores the order of the elements of all slices, by putting them in a
+// canonical order before comparing. Protos are ordered by their serialized
+// form, and other values by how they're printed with %v. Values containing
+// pointers print as addresses, so they may not be ordered consistently; use
+// SortSlicesAt() for those. Byte slices are data rather than collections,
+// so their order still matters.
+//
+// Example:
+//
+//	ExpectThat(t, got, Equiv(want, IgnoreSliceOrder()))
+func IgnoreSliceOrder() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		t := p.Last().Type()
+		return t == nil || t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uint8
+	}, cmpopts.SortSlices(func(a, b any) bool {
+		return bytes.Compare(sortKey(a), sortKey(b)) < 0
+	}))
+}
+
+// Compares functions by which function they run, as identified by its name,
+// rather than considering all non-nil functions unequal. Closures created by
+// the same function literal are equal regardless of the variables they
+// capture, as are method values of the same method with different receivers.
+//
+// Example:
+//
+//	type Route struct { Path string; Handler http.HandlerFunc }
+//	ExpectThat(t, routes, Equiv(want, DefaultEqOptions(), CompareFuncs()))
+func CompareFuncs() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().Type().Kind() == reflect.Func
+	}, cmp.Transformer("gotest.FuncName", funcName))
+}
+
+func funcName(f any) string {
+	r := reflect.ValueOf(f)
+	if r.IsNil() {
+		return "<nil>"
+	}
+	if fn := runtime.FuncForPC(r.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return fmt.Sprintf("func at %#x", r.Pointer())
+}
+
 func GetCallerPkg() (string, bool) {
 	// Find the caller's package by skipping past any frames in our own package
-	// (e.g., when called from ExpectEq, we want the test package, not gotest)
+	// or its subpackages (e.g., when called from ExpectEq, we want the test
+	// package, not gotest). External test packages are callers like any other.
 	skip := 1
 	var callerPkg string
 	eqPkg := getPackageName(getCurrentPC())
@@ -108,16 +550,147 @@ func GetCallerPkg() (string, bool) {
 			return "", false
 		}
 		callerPkg = getPackageName(pc)
-		if callerPkg != eqPkg {
+		inSubpackage := strings.HasPrefix(callerPkg, eqPkg+"/") && !strings.HasSuffix(callerPkg, "_test")
+		if callerPkg != eqPkg && !inSubpackage {
 			return callerPkg, true
 		}
 		skip++
 	}
 }
 
+// Configures Eq() and its variants.
+type EqOption func(*eqMatcher)
+
+// How Eq() and Equiv() describe the differences between the wanted and actual
+// values when they don't match.
+type DiffStyle int
+
+const (
+	// A full diff of the two values, as produced by cmp.Diff.
+	FullDiff DiffStyle = iota + 1
+	// A list of the paths at which the values differ, along with the wanted
+	// and actual values at each. This is much shorter than a full diff for
+	// large structs.
+	PathDiff
+	// A PathDiff, followed by a FullDiff.
+	PathAndFullDiff
+)
+
+// The DiffStyle used by matchers that don't set one with WithDiffStyle(). It
+// should only be changed before any tests run, e.g. in TestMain().
+var DefaultDiffStyle = FullDiff
+
+// Sets how differences are described when the value doesn't match.
+//
+// Example:
+//
+//	ExpectThat(t, deployment, Eq(want, WithDiffStyle(PathDiff)))
+func WithDiffStyle(style DiffStyle) EqOption {
+	return func(m *eqMatcher) {
+		m.diffStyle = style
+	}
+}
+
+// Adds cmp.Options to those that Eq() compares with, as if the value were
+// compared with Equiv(x, DefaultEqOptions(), opts...).
+//
+// Example:
+//
+//	ExpectThat(t, handlers, Eq(want, WithCmpOptions(CompareFuncs())))
+func WithCmpOptions(opts ...cmp.Option) EqOption {
+	return func(m *eqMatcher) {
+		m.opts = append(slices.Clip(m.opts), opts...)
+	}
+}
+
+// Compares the unexported fields of the types of `values`, which Eq() would
+// otherwise ignore because they're defined in a different package. This is
+// useful for third-party types whose meaningful state is unexported.
+//
+// Each value must be a struct, or a pointer to one. Only structs have fields,
+// so other types cause a panic.
+//
+// Equiv() doesn't ignore unexported fields by itself; use cmp.AllowUnexported
+// there instead.
+//
+// Example:
+//
+//	ExpectThat(t, got, Eq(want, ExposeUnexported(money.Amount{}, ledger.Entry{})))
+func ExposeUnexported(values ...any) EqOption {
+	types := make([]reflect.Type, len(values))
+	for i, v := range values {
+		t := reflect.TypeOf(v)
+		if t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("ExposeUnexported: expected a struct or a pointer to one, got %T", v))
+		}
+		types[i] = t
+	}
+	return func(m *eqMatcher) {
+		m.exposed = append(m.exposed, types...)
+	}
+}
+
+// Compares unexported fields as if Eq() were called from the package with
+// import path `pkg`. Eq() normally finds the calling package automatically,
+// but that's the wrong one when it's called from a helper in a shared package
+// on behalf of a test elsewhere.
+//
+// Example:
+//
+//	// In package testutil:
+//	func ExpectSameUser(t *testing.T, got, want any) {
+//		pkg := reflect.TypeOf(want).PkgPath()
+//		ExpectThat(t, got, Eq(want, WithCallerPackage(pkg)))
+//	}
+func WithCallerPackage(pkg string) EqOption {
+	return func(m *eqMatcher) {
+		m.callerPkg = pkg
+	}
+}
+
+// Fails the match, rather than ignoring them, if the values have unexported
+// fields that Eq() wouldn't compare: those of types from other packages that
+// aren't passed to ExposeUnexported(). This guards against values that only
+// seem equal because all of their meaningful state is unexported.
+//
+// Example:
+//
+//	ExpectThat(t, got, Eq(want, Strict(), ExposeUnexported(money.Amount{})))
+func Strict() EqOption {
+	return func(m *eqMatcher) {
+		m.strict = true
+	}
+}
+
+// Maximum number of differing paths listed by a PathDiff.
+const maxDiffPaths = 10
+
+// Returns an Eq() matcher, comparing with the default options for `callerPkg`
+// along with `cmpOpts`, as configured by `opts`.
+func newEqMatcher(x any, callerPkg string, cmpOpts []cmp.Option, opts []EqOption) eqMatcher {
+	m := eqMatcher{val: x, opts: cmpOpts, callerPkg: callerPkg}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	m.opts = append(defaultEqOptions(m.callerPkg, m.exposed...), m.opts...)
+	return m
+}
+
 type eqMatcher struct {
-	val  any
-	opts []cmp.Option
+	val       any
+	opts      []cmp.Option
+	diffStyle DiffStyle
+
+	// The package whose types' unexported fields are compared, along with
+	// those of the types in `exposed`.
+	callerPkg string
+	exposed   []reflect.Type
+
+	// Whether to fail rather than ignore other unexported fields.
+	strict bool
 }
 
 func (e eqMatcher) String() string {
@@ -125,15 +698,132 @@ func (e eqMatcher) String() string {
 }
 
 func (e eqMatcher) Matches(x any) bool {
+	if e.strict && len(e.hiddenFields(x)) > 0 {
+		return false
+	}
 	return cmp.Equal(x, e.val, e.opts...)
 }
 
+// Returns the names of the unexported fields that comparing with `x` ignores,
+// like "pkg.Type.field".
+func (e eqMatcher) hiddenFields(x any) []string {
+	var fields []string
+	recorder := cmp.FilterPath(func(p cmp.Path) bool {
+		if isHiddenField(p, e.callerPkg, e.exposed) {
+			field := fmt.Sprintf("%s.%s", p.Index(-2).Type(), p.Last().(cmp.StructField).Name())
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+		return false
+	}, cmp.Ignore())
+	// cmp stops evaluating options at the first one that ignores a path, so
+	// the recorder has to come before the defaults.
+	cmp.Equal(e.val, x, append([]cmp.Option{recorder}, e.opts...)...)
+	return fields
+}
+
 func (e eqMatcher) ExplainFailure(x any) (string, bool) {
-	diff := cmp.Diff(e.val, x, e.opts...)
-	if diff == "" {
+	style := e.diffStyle
+	if style == 0 {
+		style = DefaultDiffStyle
+	}
+	var sections []string
+	if e.strict {
+		if fields := e.hiddenFields(x); len(fields) > 0 {
+			sections = append(sections, fmt.Sprintf(
+				"comparison would ignore unexported fields of types from other packages: %s (use ExposeUnexported() to compare them)",
+				strings.Join(fields, ", ")))
+		}
+	}
+	if style == PathDiff || style == PathAndFullDiff {
+		r := &pathReporter{}
+		cmp.Equal(e.val, x, append(e.opts, cmp.Reporter(r))...)
+		if len(r.diffs) > 0 {
+			sections = append(sections, "doesn't match at (want != got):\n"+r.String())
+		}
+	}
+	if style != PathDiff {
+		if diff := cmp.Diff(e.val, x, e.opts...); diff != "" {
+			sections = append(sections, fmt.Sprintf("doesn't match (-want +got):\n%s", diff))
+		}
+	}
+	if len(sections) == 0 {
 		return "", false
 	}
-	return fmt.Sprintf("doesn't match (-want +got):\n%s", diff), true
+	return strings.Join(sections, "\n"), true
+}
+
+// A cmp.Reporter that records the path to, and values of, each difference.
+type pathReporter struct {
+	path  cmp.Path
+	diffs []string
+}
+
+func (r *pathReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *pathReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	want, got := r.path.Last().Values()
+	r.diffs = append(r.diffs, fmt.Sprintf("%s: %s != %s", readablePath(r.path), formatDiffValue(want), formatDiffValue(got)))
+}
+
+func (r *pathReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *pathReporter) String() string {
+	lines := r.diffs
+	if len(lines) > maxDiffPaths {
+		lines = append(lines[:maxDiffPaths:maxDiffPaths], fmt.Sprintf("...and %d more", len(r.diffs)-maxDiffPaths))
+	}
+	return "    " + strings.Join(lines, "\n    ")
+}
+
+// Returns a path like "Spec.Containers[0].Ports", omitting the steps that
+// don't change which part of the value is being looked at, such as pointer
+// indirections and transformations.
+func readablePath(p cmp.Path) string {
+	var b strings.Builder
+	for i, step := range p {
+		switch s := step.(type) {
+		case cmp.StructField:
+			fmt.Fprintf(&b, ".%s", s.Name())
+		case cmp.SliceIndex:
+			// An element that's only in one of the slices has no index in the
+			// other.
+			index, otherIndex := s.SplitKeys()
+			if index == -1 {
+				index = otherIndex
+			}
+			fmt.Fprintf(&b, "[%d]", index)
+		case cmp.MapIndex:
+			// Protos are transformed into maps from field names to values.
+			if i > 0 && p[i-1].Type() == protocmpMessageType {
+				fmt.Fprintf(&b, ".%v", s.Key())
+			} else {
+				fmt.Fprintf(&b, "[%#v]", s.Key())
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return "value"
+	}
+	return strings.TrimPrefix(b.String(), ".")
+}
+
+func formatDiffValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<missing>"
+	}
+	if v.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+	return fmt.Sprintf("%v", v)
 }
 
 func getCurrentPC() uintptr {
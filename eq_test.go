@@ -1,8 +1,20 @@
 package gotest_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
 
@@ -208,3 +220,468 @@ func TestEqual_Protos(t *testing.T) {
 		Not(Eq(x{anyList: []any{differentProto, "string", 123}})),
 	)
 }
+
+func TestEqual_ProtoDiffs(t *testing.T) {
+	want := x{Proto: &testdata.SomeData{A: "test", Recursive: &testdata.SomeData{I: 1}}}
+	got := x{Proto: &testdata.SomeData{A: "test", Recursive: &testdata.SomeData{I: 2}}}
+
+	explanation, ok := Eq(want).(MismatchExplainer).ExplainFailure(got)
+	ExpectEq(t, ok, true)
+	ExpectThat(t, explanation, AllOf(
+		HasSubstr(`"recursive": protocmp.Message{`),
+		ContainsRegex(`-.*"i":.*int32\(1\)`),
+		ContainsRegex(`\+.*"i":.*int32\(2\)`),
+	))
+}
+
+func TestEqual_BigNumbers(t *testing.T) {
+	ExpectEq(t, big.NewInt(5), big.NewInt(5))
+	ExpectThat(t, big.NewInt(5), Not(Eq(big.NewInt(6))))
+	ExpectEq(t, big.NewRat(1, 2), big.NewRat(2, 4))
+	ExpectThat(t, big.NewRat(1, 2), Not(Eq(big.NewRat(1, 3))))
+	ExpectEq(t, big.NewFloat(1.5), new(big.Float).SetPrec(200).SetFloat64(1.5))
+	ExpectThat(t, big.NewFloat(1.5), Not(Eq(big.NewFloat(2.5))))
+	ExpectThat(t, (*big.Int)(nil), Not(Eq(big.NewInt(0))))
+
+	// Nested within other values
+	ExpectEq(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	ExpectThat(t,
+		map[string]*big.Int{"a": big.NewInt(1)},
+		Not(Eq(map[string]*big.Int{"a": big.NewInt(2)})),
+	)
+}
+
+type point struct {
+	X, Y  float64
+	label string
+	z     float32
+}
+
+func TestEqApprox(t *testing.T) {
+	a, b := 0.1, 0.2
+	ExpectThat(t, a+b, Not(Eq(0.3)))
+	ExpectThat(t, a+b, EqApprox(0.3, 1e-9))
+	ExpectThat(t, 0.35, Not(EqApprox(0.3, 0.01)))
+	ExpectThat(t, float32(1.5), EqApprox(float32(1.51), 0.1))
+	ExpectThat(t, math.NaN(), Not(EqApprox(math.NaN(), 1)))
+
+	// Nested floats, including in unexported fields
+	ExpectThat(t, point{X: a + b, Y: 1, z: 2.0001}, EqApprox(point{X: 0.3, Y: 1, z: 2}, 1e-3))
+	ExpectThat(t, point{X: 0.3, Y: 1, label: "a"}, Not(EqApprox(point{X: 0.3, Y: 1, label: "b"}, 1)))
+	ExpectThat(t, []float64{1.0, 2.05}, EqApprox([]float64{1, 2}, 0.1))
+	ExpectThat(t, map[string]float64{"a": 1.01}, EqApprox(map[string]float64{"a": 1}, 0.1))
+	ExpectThat(t, map[string]float64{"a": 1.5}, Not(EqApprox(map[string]float64{"a": 1}, 0.1)))
+
+	// As an option to Equiv
+	ExpectThat(t, []float64{1.0, 2.05}, Equiv([]float64{1, 2}, WithFloatTolerance(0.1)))
+	ExpectThat(t, []float64{1.0, 2.5}, Not(Equiv([]float64{1, 2}, WithFloatTolerance(0.1))))
+}
+
+type inventory struct {
+	Items  []string
+	Counts map[string]int
+	owners []string
+}
+
+func TestEqLoose(t *testing.T) {
+	ExpectThat(t, []int{}, Not(Eq([]int(nil))))
+	ExpectThat(t, []int{}, EqLoose([]int(nil)))
+	ExpectThat(t, map[string]int(nil), EqLoose(map[string]int{}))
+	ExpectThat(t, []int{1}, Not(EqLoose([]int(nil))))
+
+	// Nested collections, including in unexported fields
+	ExpectThat(t, inventory{Items: []string{}, Counts: map[string]int{}}, EqLoose(inventory{}))
+	ExpectThat(t, inventory{owners: []string{}}, EqLoose(inventory{}))
+	ExpectThat(t, inventory{owners: []string{"a"}}, Not(EqLoose(inventory{})))
+	ExpectThat(t, []inventory{{}}, EqLoose([]inventory{{Items: []string{}}}))
+
+	// As an option to Equiv
+	ExpectThat(t, []int{}, Equiv([]int(nil), NilEqualsEmpty()))
+}
+
+func TestEqNumeric(t *testing.T) {
+	ExpectThat(t, int64(5), Not(Eq(5)))
+	ExpectThat(t, int64(5), EqNumeric(5))
+	ExpectThat(t, 5.0, EqNumeric(5))
+	ExpectThat(t, uint8(5), EqNumeric(int32(5)))
+	ExpectThat(t, 5.5, Not(EqNumeric(5)))
+	ExpectThat(t, -1, Not(EqNumeric(uint64(math.MaxUint64))))
+	ExpectThat(t, big.NewInt(5), EqNumeric(5))
+	ExpectThat(t, "5", Not(EqNumeric(5)))
+
+	// Nested within interfaces, e.g. from decoding JSON
+	var decoded map[string]any
+	AssertNoError(t, json.Unmarshal([]byte(`{"count": 3, "ratio": 0.5, "tags": [1, 2]}`), &decoded))
+	ExpectThat(t, decoded, Not(Eq(map[string]any{"count": 3, "ratio": 0.5, "tags": []any{1, 2}})))
+	ExpectThat(t, decoded, EqNumeric(map[string]any{"count": 3, "ratio": 0.5, "tags": []any{1, 2}}))
+	ExpectThat(t, decoded, Not(EqNumeric(map[string]any{"count": 4, "ratio": 0.5, "tags": []any{1, 2}})))
+}
+
+type Port struct {
+	Name   string
+	Number int
+}
+
+type Service struct {
+	Ports  []Port
+	Hosts  []string
+	Protos []*testdata.SomeData
+}
+
+func TestSortSlicesAt(t *testing.T) {
+	byNumber := func(a, b Port) bool { return a.Number < b.Number }
+	got := Service{Ports: []Port{{"https", 443}, {"http", 80}}, Hosts: []string{"a", "b"}}
+	want := Service{Ports: []Port{{"http", 80}, {"https", 443}}, Hosts: []string{"a", "b"}}
+	ExpectThat(t, got, Not(Equiv(want)))
+	ExpectThat(t, got, Equiv(want, SortSlicesAt("Ports", byNumber)))
+
+	// Only the slices at the path are sorted.
+	want.Hosts = []string{"b", "a"}
+	ExpectThat(t, got, Not(Equiv(want, SortSlicesAt("Ports", byNumber))))
+
+	// Nested within other slices
+	ExpectThat(t,
+		[]Service{{Ports: []Port{{"https", 443}, {"http", 80}}}},
+		Equiv([]Service{{Ports: []Port{{"http", 80}, {"https", 443}}}}, SortSlicesAt("Ports", byNumber)),
+	)
+}
+
+func TestIgnoreSliceOrder(t *testing.T) {
+	ExpectThat(t, []int{3, 1, 2}, Equiv([]int{1, 2, 3}, IgnoreSliceOrder()))
+	ExpectThat(t, []int{3, 1, 1}, Not(Equiv([]int{1, 3, 3}, IgnoreSliceOrder())))
+	ExpectThat(t,
+		Service{Ports: []Port{{"https", 443}, {"http", 80}}, Hosts: []string{"b", "a"}},
+		Equiv(Service{Ports: []Port{{"http", 80}, {"https", 443}}, Hosts: []string{"a", "b"}}, IgnoreSliceOrder()),
+	)
+	ExpectThat(t,
+		Service{Protos: []*testdata.SomeData{{A: "y"}, {A: "x"}}},
+		Equiv(Service{Protos: []*testdata.SomeData{{A: "x"}, {A: "y"}}}, IgnoreSliceOrder(), CompareProtos()),
+	)
+
+	// Byte slices are data, whose order matters.
+	ExpectThat(t, []byte{1, 2}, Not(Equiv([]byte{2, 1}, IgnoreSliceOrder())))
+	ExpectThat(t, [][]byte{{3}, {1, 2}}, Equiv([][]byte{{1, 2}, {3}}, IgnoreSliceOrder()))
+	ExpectThat(t, [][]byte{{1, 2}}, Not(Equiv([][]byte{{2, 1}}, IgnoreSliceOrder())))
+}
+
+type deployment struct {
+	Name     string
+	Replicas int
+	Labels   map[string]string
+	Ports    []Port
+	owner    string
+}
+
+func TestEq_PathDiff(t *testing.T) {
+	want := deployment{Name: "web", Replicas: 3, Labels: map[string]string{"app": "web"}, Ports: []Port{{"http", 80}}, owner: "a"}
+	got := deployment{Name: "web", Replicas: 5, Labels: map[string]string{"app": "db"}, owner: "b"}
+
+	explanation, _ := Eq(want, WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(got)
+	ExpectEq(t, explanation, strings.Join([]string{
+		"doesn't match at (want != got):",
+		`    Replicas: 3 != 5`,
+		`    Labels["app"]: "web" != "db"`,
+		`    Ports: [{http 80}] != []`,
+		`    owner: "a" != "b"`,
+	}, "\n"))
+
+	explanation, _ = Eq(want, WithDiffStyle(PathAndFullDiff)).(MismatchExplainer).ExplainFailure(got)
+	ExpectThat(t, explanation, AllOf(
+		StartsWith("doesn't match at (want != got):\n    Replicas: 3 != 5\n"),
+		HasSubstr("\ndoesn't match (-want +got):\n"),
+	))
+
+	// Protos are described by their field names.
+	explanation, _ = Eq(x{Proto: &testdata.SomeData{A: "a"}}, WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(
+		x{Proto: &testdata.SomeData{A: "b"}})
+	ExpectEq(t, explanation, "doesn't match at (want != got):\n    Proto.a: \"a\" != \"b\"")
+
+	// Long lists are cut short.
+	explanation, _ = Eq(make([]int, 15), WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(
+		[]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+	ExpectThat(t, strings.Split(explanation, "\n"), AllOf(
+		Len(12),
+		Contains("    [9]: 0 != 1"),
+		Contains("    ...and 5 more"),
+	))
+
+	// The default can be changed for all matchers.
+	defer func(style DiffStyle) { DefaultDiffStyle = style }(DefaultDiffStyle)
+	DefaultDiffStyle = PathDiff
+	explanation, _ = Eq(3).(MismatchExplainer).ExplainFailure(5)
+	ExpectEq(t, explanation, "doesn't match at (want != got):\n    value: 3 != 5")
+	explanation, _ = Eq(3, WithDiffStyle(FullDiff)).(MismatchExplainer).ExplainFailure(5)
+	ExpectThat(t, explanation, StartsWith("doesn't match (-want +got):\n"))
+}
+
+func TestDefaultEqOptions(t *testing.T) {
+	want := deployment{Name: "web", Replicas: 3, owner: "a"}
+	ExpectThat(t, deployment{Name: "web", Replicas: 3, owner: "a"}, Equiv(want, DefaultEqOptions()))
+	ExpectThat(t, deployment{Name: "web", Replicas: 3, owner: "b"}, Not(Equiv(want, DefaultEqOptions())))
+	ExpectThat(t,
+		deployment{Name: "web", Replicas: 5, owner: "a"},
+		Equiv(want, DefaultEqOptions(), cmpopts.IgnoreFields(deployment{}, "Replicas")),
+	)
+	ExpectThat(t,
+		deployment{Name: "web", Replicas: 3, owner: "a", Ports: []Port{}},
+		Equiv(want, DefaultEqOptions(), NilEqualsEmpty()),
+	)
+	ExpectThat(t,
+		x{Proto: &testdata.SomeData{L: nil}},
+		Equiv(x{Proto: &testdata.SomeData{L: []string{}}}, DefaultEqOptions()),
+	)
+}
+
+func TestExposeUnexported(t *testing.T) {
+	external1 := testdata.NewExternalType("public", "private1")
+	external2 := testdata.NewExternalType("public", "private2")
+	ExpectEq(t, external1, external2)
+	ExpectThat(t, external1, Not(Eq(external2, ExposeUnexported(testdata.ExternalType{}))))
+	ExpectThat(t, external1, Eq(external1, ExposeUnexported(testdata.ExternalType{})))
+
+	// Nested within other values
+	ExpectThat(t,
+		[]testdata.ExternalType{external1},
+		Not(EqLoose([]testdata.ExternalType{external2}, ExposeUnexported(testdata.ExternalType{}))),
+	)
+	ExpectThat(t,
+		map[string]testdata.ExternalType{"a": external1},
+		Eq(map[string]testdata.ExternalType{"a": external2}, ExposeUnexported(point{})),
+	)
+
+	explanation, _ := Eq(external2, ExposeUnexported(testdata.ExternalType{}), WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(external1)
+	ExpectEq(t, explanation, "doesn't match at (want != got):\n    privateField: \"private2\" != \"private1\"")
+
+	// Pointers expose the types they point to.
+	ExpectThat(t, external1, Not(Eq(external2, ExposeUnexported(&testdata.ExternalType{}))))
+	ExpectThat(t, &external1, Not(Eq(&external2, ExposeUnexported(&testdata.ExternalType{}))))
+
+	ExpectFatal(t, Eq("ExposeUnexported: expected a struct or a pointer to one, got string"), func() {
+		ExposeUnexported("not a struct")
+	})
+	ExpectFatal(t, Eq("ExposeUnexported: expected a struct or a pointer to one, got <nil>"), func() {
+		ExposeUnexported(nil)
+	})
+}
+
+func TestWithCallerPackage(t *testing.T) {
+	external1 := testdata.NewExternalType("public", "private1")
+	external2 := testdata.NewExternalType("public", "private2")
+	testdataPkg := reflect.TypeOf(external1).PkgPath()
+	ExpectThat(t, external1, Not(Eq(external2, WithCallerPackage(testdataPkg))))
+	ExpectThat(t, external1, Eq(external1, WithCallerPackage(testdataPkg)))
+
+	// This package's unexported fields are then ignored.
+	ExpectThat(t, point{X: 1, label: "a"}, Eq(point{X: 1, label: "b"}, WithCallerPackage(testdataPkg)))
+	ExpectThat(t, point{X: 1, label: "a"}, Not(Eq(point{X: 1, label: "b"})))
+}
+
+type ledger struct {
+	Owner   string
+	Entries []testdata.ExternalType
+	notes   string
+}
+
+func TestStrict(t *testing.T) {
+	external1 := testdata.NewExternalType("public", "private1")
+	external2 := testdata.NewExternalType("public", "private2")
+	ExpectThat(t, ledger{Owner: "a"}, Eq(ledger{Owner: "a"}, Strict()))
+	ExpectThat(t, ledger{notes: "a"}, Not(Eq(ledger{notes: "b"}, Strict())))
+	ExpectThat(t, external1, Not(Eq(external1, Strict())))
+	ExpectThat(t, external1, Eq(external1, Strict(), ExposeUnexported(testdata.ExternalType{})))
+	ExpectThat(t, external1, Not(Eq(external2, Strict(), ExposeUnexported(testdata.ExternalType{}))))
+
+	explanation, _ := Eq(ledger{Entries: []testdata.ExternalType{external1, external2}}, Strict()).(MismatchExplainer).ExplainFailure(
+		ledger{Entries: []testdata.ExternalType{external1, external1}})
+	ExpectEq(t, explanation, "comparison would ignore unexported fields of types from other packages: "+
+		"testdata.ExternalType.privateField (use ExposeUnexported() to compare them)")
+
+	explanation, _ = Eq(ledger{Owner: "a", Entries: []testdata.ExternalType{external1}}, Strict(), WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(
+		ledger{Owner: "b", Entries: []testdata.ExternalType{external1}})
+	ExpectThat(t, strings.Split(explanation, "\n"), ElementsAre(
+		StartsWith("comparison would ignore unexported fields"),
+		"doesn't match at (want != got):",
+		`    Owner: "a" != "b"`,
+	))
+}
+
+type route struct {
+	Path    string
+	Handler func() string
+}
+
+func home() string  { return "home" }
+func about() string { return "about" }
+
+func TestCompareFuncs(t *testing.T) {
+	ExpectThat(t, route{"/", home}, Not(Eq(route{"/", home})))
+	ExpectThat(t, route{"/", home}, Eq(route{"/", home}, WithCmpOptions(CompareFuncs())))
+	ExpectThat(t, route{"/", home}, Not(Eq(route{"/", about}, WithCmpOptions(CompareFuncs()))))
+	ExpectThat(t, route{"/", nil}, Eq(route{"/", nil}, WithCmpOptions(CompareFuncs())))
+	ExpectThat(t, route{"/", nil}, Not(Eq(route{"/", home}, WithCmpOptions(CompareFuncs()))))
+	ExpectThat(t, []func() string{home, about}, Equiv([]func() string{home, about}, CompareFuncs()))
+
+	// Closures from the same literal are the same function.
+	makeHandler := func(s string) func() string { return func() string { return s } }
+	ExpectThat(t, route{"/", makeHandler("a")}, Eq(route{"/", makeHandler("b")}, WithCmpOptions(CompareFuncs())))
+
+	explanation, _ := Eq(route{"/", home}, WithCmpOptions(CompareFuncs()), WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(route{"/", about})
+	ExpectEq(t, explanation, strings.Join([]string{
+		"doesn't match at (want != got):",
+		`    Handler: "github.com/jfmatt/gotest_test.home" != "github.com/jfmatt/gotest_test.about"`,
+	}, "\n"))
+}
+
+// Only the amount is significant; the note isn't.
+type money struct {
+	cents int
+	note  string
+}
+
+func (m *money) Equal(o *money) bool {
+	if m == nil || o == nil {
+		return m == o
+	}
+	return m.cents == o.cents
+}
+
+type account struct {
+	Balance  money
+	Pending  []money
+	Limits   map[string]money
+	Previous *money
+	Opened   time.Time
+}
+
+func TestEqual_EqualMethods(t *testing.T) {
+	// Value receivers
+	opened := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ExpectEq(t, account{Opened: opened}, account{Opened: opened.In(time.FixedZone("CET", 3600))})
+	ExpectThat(t, account{Opened: opened}, Not(Eq(account{Opened: opened.Add(time.Second)})))
+
+	// Pointer receivers, for values that aren't pointers
+	ExpectEq(t, money{5, "a"}, money{5, "b"})
+	ExpectThat(t, money{5, "a"}, Not(Eq(money{6, "a"})))
+	ExpectEq(t,
+		account{Balance: money{5, "a"}, Pending: []money{{1, "a"}}, Limits: map[string]money{"daily": {9, "a"}}},
+		account{Balance: money{5, "b"}, Pending: []money{{1, "b"}}, Limits: map[string]money{"daily": {9, "b"}}},
+	)
+	ExpectThat(t,
+		account{Pending: []money{{1, "a"}}},
+		Not(Eq(account{Pending: []money{{2, "a"}}})),
+	)
+	ExpectEq(t, account{Previous: &money{5, "a"}}, account{Previous: &money{5, "b"}})
+}
+
+func TestEqual_Times(t *testing.T) {
+	now := time.Now()
+	ExpectEq(t, now, now.Round(0))
+	ExpectEq(t, now, now.In(time.FixedZone("CET", 3600)))
+	ExpectThat(t, now, Not(Eq(now.Add(time.Nanosecond))))
+
+	parsed, err := time.Parse(time.RFC3339Nano, now.Format(time.RFC3339Nano))
+	AssertNoError(t, err)
+	ExpectEq(t, []time.Time{now}, []time.Time{parsed})
+	ExpectThat(t, []time.Time{now}, Equiv([]time.Time{parsed}, CompareTimes()))
+	ExpectEq(t, &now, &parsed)
+}
+
+type event struct {
+	Kind    string
+	Payload []byte
+	Meta    json.RawMessage
+	Extra   map[string]json.RawMessage
+}
+
+func TestEqual_RawJSON(t *testing.T) {
+	ExpectEq(t, json.RawMessage(`{"a": 1, "b": [1, 2]}`), json.RawMessage(`{"b":[1,2],"a":1}`))
+	ExpectThat(t, json.RawMessage(`{"a": 1}`), Not(Eq(json.RawMessage(`{"a": 2}`))))
+	ExpectEq(t,
+		event{Meta: json.RawMessage(`{"a": 1, "b": 2}`), Extra: map[string]json.RawMessage{"x": []byte(` [1] `)}},
+		event{Meta: json.RawMessage(`{"b":2,"a":1}`), Extra: map[string]json.RawMessage{"x": []byte(`[1]`)}},
+	)
+	ExpectThat(t, json.RawMessage(`not json`), Eq(json.RawMessage(`not json`)))
+	ExpectThat(t, json.RawMessage(`not json`), Not(Eq(json.RawMessage(`not  json`))))
+
+	// Other byte slices are still compared byte by byte, unless they're
+	// specified to contain JSON.
+	ExpectThat(t, event{Payload: []byte(`{"a": 1}`)}, Not(Eq(event{Payload: []byte(`{"a":1}`)})))
+	ExpectThat(t, event{Payload: []byte(`{"a": 1}`)}, Eq(event{Payload: []byte(`{"a":1}`)}, WithCmpOptions(CompareAsJSON("Payload"))))
+	ExpectThat(t, event{Kind: `{"a": 1}`}, Eq(event{Kind: `{"a":1}`}, WithCmpOptions(CompareAsJSON("Kind"))))
+	ExpectThat(t, event{Kind: `{"a": 1}`}, Not(Eq(event{Kind: `{"a":2}`}, WithCmpOptions(CompareAsJSON("Kind")))))
+	ExpectThat(t, event{Kind: `not json`}, Eq(event{Kind: `not json`}, WithCmpOptions(CompareAsJSON("Kind"))))
+	ExpectThat(t, event{Kind: `not json`}, Not(Eq(event{Kind: `not  json`}, WithCmpOptions(CompareAsJSON("Kind")))))
+	ExpectThat(t, event{Payload: []byte(`"a"`)}, Eq(event{Payload: []byte(` "a"`)}, WithCmpOptions(CompareAsJSON("Payload"))))
+}
+
+type host struct {
+	Name string
+	Addr netip.Addr
+}
+
+// Marshals with a pointer receiver, and can fail.
+type ticket struct {
+	id int
+}
+
+func (t *ticket) MarshalJSON() ([]byte, error) {
+	if t.id < 0 {
+		return nil, errors.New("negative id")
+	}
+	return []byte(strconv.Itoa(t.id)), nil
+}
+
+func TestCompareMarshaled(t *testing.T) {
+	addr1 := netip.MustParseAddr("10.0.0.1")
+	addr2 := netip.MustParseAddr("10.0.0.2")
+	// Without the option, the addresses' unexported fields are ignored.
+	ExpectThat(t, host{Addr: addr1}, Eq(host{Addr: addr2}))
+
+	compareMarshaled := WithCmpOptions(CompareMarshaled())
+	ExpectThat(t, host{Addr: addr1}, Eq(host{Addr: addr1}, compareMarshaled))
+	ExpectThat(t, host{Addr: addr1}, Not(Eq(host{Addr: addr2}, compareMarshaled)))
+	ExpectThat(t, addr1, Not(Equiv(addr2, CompareMarshaled())))
+
+	explanation, _ := Eq(host{Addr: addr1}, compareMarshaled, WithDiffStyle(PathDiff)).(MismatchExplainer).ExplainFailure(host{Addr: addr2})
+	ExpectThat(t, explanation, ContainsRegex(`Addr.*: "10.0.0.1" != "10.0.0.2"`))
+
+	ExpectThat(t, ticket{1}, Eq(ticket{1}, compareMarshaled))
+	ExpectThat(t, ticket{1}, Not(Eq(ticket{2}, compareMarshaled)))
+	ExpectThat(t, ticket{-1}, Eq(ticket{-1}, compareMarshaled))
+	ExpectThat(t, ticket{-1}, Not(Eq(ticket{-2}, compareMarshaled)))
+}
+
+type result struct {
+	Value int
+	Err   error
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestEquateErrors(t *testing.T) {
+	equateErrors := WithCmpOptions(EquateErrors())
+	ExpectThat(t, result{Err: io.EOF}, Eq(result{Err: io.EOF}, equateErrors))
+	ExpectThat(t, result{Err: fmt.Errorf("reading: %w", io.EOF)}, Eq(result{Err: io.EOF}, equateErrors))
+	ExpectThat(t, result{Err: io.EOF}, Eq(result{Err: fmt.Errorf("reading: %w", io.EOF)}, equateErrors))
+	ExpectThat(t, result{Err: errors.New("failed")}, Eq(result{Err: errors.New("failed")}, equateErrors))
+	ExpectThat(t, result{Err: &codeError{1}}, Eq(result{Err: &codeError{1}}, equateErrors))
+	ExpectThat(t, result{Err: &codeError{1}}, Not(Eq(result{Err: &codeError{2}}, equateErrors)))
+	ExpectThat(t, result{Err: io.EOF}, Not(Eq(result{Err: io.ErrUnexpectedEOF}, equateErrors)))
+	ExpectThat(t, result{Err: io.EOF}, Not(Eq(result{}, equateErrors)))
+	ExpectThat(t, result{}, Eq(result{}, equateErrors))
+	ExpectThat(t, result{Value: 1}, Not(Eq(result{Value: 2}, equateErrors)))
+
+	var nilCodeError *codeError
+	ExpectThat(t, []*codeError{nilCodeError}, Eq([]*codeError{nil}, equateErrors))
+	ExpectThat(t, []*codeError{nilCodeError}, Not(Eq([]*codeError{{1}}, equateErrors)))
+
+	// Without the option, the unexported fields of the errors are ignored.
+	ExpectThat(t, result{Err: errors.New("failed")}, Eq(result{Err: errors.New("other")}))
+	ExpectThat(t, result{Err: errors.New("failed")}, Not(Eq(result{Err: errors.New("other")}, equateErrors)))
+}
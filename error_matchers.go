@@ -3,6 +3,8 @@ package gotest
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // ErrorMessage matches errors whose error message fulfills the innerMatcher.
@@ -66,3 +68,198 @@ func (e errIsMatcher) Matches(x any) bool {
 func (e errIsMatcher) String() string {
 	return fmt.Sprintf("is an error wrapping %s", e.err)
 }
+
+// NoError matches nil errors. On failure, the whole chain of wrapped errors is
+// shown, one layer per line.
+//
+// Examples:
+//
+//	f, err := os.Open(path)
+//	ExpectThat(t, err, NoError())
+//	ExpectThat(t, errors.New("oops"), Not(NoError()))
+func NoError() Matcher {
+	return noErrorMatcher{}
+}
+
+type noErrorMatcher struct{}
+
+func (noErrorMatcher) Matches(x any) bool {
+	return x == nil
+}
+
+func (noErrorMatcher) String() string {
+	return "is no error"
+}
+
+func (noErrorMatcher) ExplainFailure(x any) (string, bool) {
+	err, ok := x.(error)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not an error", x), true
+	}
+	if r := reflect.ValueOf(err); r.Kind() == reflect.Pointer && r.IsNil() {
+		return fmt.Sprintf("error is a nil %T, which isn't the same as a nil error", x), true
+	}
+	var lines []string
+	describeErrorChain(err, 2, nil, &lines)
+	return "error chain is:" + strings.Join(lines, ""), true
+}
+
+// Appends a line describing each error in the chain wrapped by `err`,
+// indenting errors joined by errors.Join() beneath the error that joins them.
+// If `annotate` is non-nil, its result (if any) is added to each line.
+func describeErrorChain(err error, indent int, annotate func(error) string, lines *[]string) {
+	for err != nil {
+		line := fmt.Sprintf("\n%s%s (%T)", strings.Repeat("  ", indent), err, err)
+		if annotate != nil {
+			if note := annotate(err); note != "" {
+				line += ", " + note
+			}
+		}
+		*lines = append(*lines, line)
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range wrapped.Unwrap() {
+				describeErrorChain(e, indent+1, annotate, lines)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
+
+// Returns `err` and every error it wraps, directly or indirectly, including
+// each of the errors joined by errors.Join().
+func errorChain(err error) []error {
+	var chain []error
+	for err != nil {
+		chain = append(chain, err)
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range wrapped.Unwrap() {
+				chain = append(chain, errorChain(e)...)
+			}
+			return chain
+		default:
+			return chain
+		}
+	}
+	return chain
+}
+
+// ErrorChainContains matches errors where the error itself, or any error it
+// wraps (including each of the errors joined by errors.Join()), fulfills
+// `innerMatcher`.
+//
+// On failure, the chain is shown as a tree. Errors that `innerMatcher` can
+// explain a mismatch for are annotated with the explanation, which usually
+// points out the near misses.
+//
+// Examples:
+//
+//	err := fmt.Errorf("loading: %w", &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist})
+//	ExpectThat(t, err, ErrorChainContains(IsA[*fs.PathError]()))
+//	ExpectThat(t, err, ErrorChainContains(ErrorMessage("file does not exist")))
+//	ExpectThat(t, err, Not(ErrorChainContains(ErrorMessage(HasSubstr("denied")))))
+func ErrorChainContains(innerMatcher any) Matcher {
+	return errChainMatcher{AsMatcher(innerMatcher)}
+}
+
+type errChainMatcher struct {
+	innerMatcher Matcher
+}
+
+func (e errChainMatcher) Matches(x any) bool {
+	asErr, ok := x.(error)
+	if !ok || asErr == nil {
+		return false
+	}
+	for _, err := range errorChain(asErr) {
+		if e.innerMatcher.Matches(err) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e errChainMatcher) String() string {
+	return fmt.Sprintf("is an error with an error in its chain which %s", e.innerMatcher.String())
+}
+
+func (e errChainMatcher) ExplainFailure(x any) (string, bool) {
+	if x == nil {
+		return "error is nil", true
+	}
+	asErr, ok := x.(error)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not an error", x), true
+	}
+	var lines []string
+	describeErrorChain(asErr, 2, func(err error) string {
+		if explainer, ok := e.innerMatcher.(MismatchExplainer); ok {
+			if expl, useE := explainer.ExplainFailure(err); useE {
+				return "where " + expl
+			}
+		}
+		return ""
+	}, &lines)
+	return "no error in the chain matches:" + strings.Join(lines, ""), true
+}
+
+// ErrorAsThat matches errors where errors.As() finds an error of type T in the
+// chain, and that error fulfills `innerMatcher`.
+//
+// Examples:
+//
+//	err := fmt.Errorf("loading: %w", &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist})
+//	ExpectThat(t, err, ErrorAsThat[*fs.PathError](Field("Path", "a.txt")))
+//	ExpectThat(t, err, ErrorAsThat[*fs.PathError](Any()))
+//	ExpectThat(t, err, Not(ErrorAsThat[*net.OpError](Any())))
+func ErrorAsThat[T error](innerMatcher any) Matcher {
+	return errAsMatcher[T]{AsMatcher(innerMatcher)}
+}
+
+type errAsMatcher[T error] struct {
+	innerMatcher Matcher
+}
+
+func (e errAsMatcher[T]) extract(x any) (T, bool) {
+	var target T
+	asErr, ok := x.(error)
+	if !ok || asErr == nil {
+		return target, false
+	}
+	return target, errors.As(asErr, &target)
+}
+
+func (e errAsMatcher[T]) Matches(x any) bool {
+	target, ok := e.extract(x)
+	return ok && e.innerMatcher.Matches(target)
+}
+
+func (e errAsMatcher[T]) String() string {
+	return fmt.Sprintf("is an error which, as %s, %s", reflect.TypeFor[T](), e.innerMatcher.String())
+}
+
+func (e errAsMatcher[T]) ExplainFailure(x any) (string, bool) {
+	if x == nil {
+		return "error is nil", true
+	}
+	asErr, ok := x.(error)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not an error", x), true
+	}
+	target, ok := e.extract(x)
+	if !ok {
+		var lines []string
+		describeErrorChain(asErr, 2, nil, &lines)
+		return fmt.Sprintf("no error in the chain is a %s:%s", reflect.TypeFor[T](), strings.Join(lines, "")), true
+	}
+	explanation := fmt.Sprintf("error is %s", formatGot(target, e.innerMatcher))
+	explanation = appendExplanation(explanation, e.innerMatcher, target)
+	return explanation, true
+}
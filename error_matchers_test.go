@@ -0,0 +1,156 @@
+package gotest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type customError struct{}
+
+func (*customError) Error() string { return "custom" }
+
+func TestNoError(t *testing.T) {
+	ExpectThat(t, nil, NoError())
+	var err error
+	ExpectThat(t, err, NoError())
+	ExpectThat(t, errors.New("oops"), Not(NoError()))
+	ExpectThat(t, "oops", Not(NoError()))
+	var nilPtr *customError
+	ExpectThat(t, error(nilPtr), Not(NoError()))
+
+	r := &testReporter{}
+	base := errors.New("permission denied")
+	ExpectThat(r, fmt.Errorf("loading config: %w", fmt.Errorf("open config.yaml: %w", base)), NoError())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is no error",
+		"  Got: loading config: open config.yaml: permission denied (*fmt.wrapError)",
+		"  ...where error chain is:",
+		"    loading config: open config.yaml: permission denied (*fmt.wrapError)",
+		"    open config.yaml: permission denied (*fmt.wrapError)",
+		"    permission denied (*errors.errorString)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, fmt.Errorf("cleanup: %w", errors.Join(base, &customError{})), NoError())
+	ExpectThat(t, r.nonFatals[0], HasSubstr(strings.Join([]string{
+		"  ...where error chain is:",
+		"    cleanup: permission denied\ncustom (*fmt.wrapError)",
+		"    permission denied\ncustom (*errors.joinError)",
+		"      permission denied (*errors.errorString)",
+		"      custom (*gotest.customError)",
+	}, "\n")))
+
+	r.Reset()
+	ExpectThat(r, error(nilPtr), NoError())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where error is a nil *gotest.customError, which isn't the same as a nil error"))
+
+	r.Reset()
+	ExpectThat(r, "oops", NoError())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not an error"))
+}
+
+func TestExpectNoError(t *testing.T) {
+	ExpectThat(t, ExpectNoError(t, nil), true)
+
+	r := &testReporter{}
+	ExpectThat(t, ExpectNoError(r, errors.New("oops")), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("...where error chain is:\n    oops (*errors.errorString)")))
+
+	r.Reset()
+	AssertNoError(r, nil)
+	ExpectThat(t, r.HasFailures(), false)
+	AssertNoError(r, errors.New("oops"))
+	ExpectThat(t, r.fatals, ElementsAre(StartsWith("Assertion failed:\n  Wanted: is no error")))
+}
+
+type statusError struct {
+	Code int
+}
+
+func (e *statusError) Error() string { return fmt.Sprintf("status %d", e.Code) }
+
+func TestErrorChainContains(t *testing.T) {
+	notFound := &statusError{404}
+	err := fmt.Errorf("fetching: %w", notFound)
+	ExpectThat(t, err, ErrorChainContains(IsA[*statusError]()))
+	ExpectThat(t, err, ErrorChainContains(ErrorMessage("status 404")))
+	ExpectThat(t, err, ErrorChainContains(ErrorIs(notFound)))
+	ExpectThat(t, err, ErrorChainContains(err))
+	ExpectThat(t, errors.Join(errors.New("a"), fmt.Errorf("b: %w", notFound)), ErrorChainContains(Field("Code", 404)))
+	ExpectThat(t, err, Not(ErrorChainContains(ErrorMessage(HasSubstr("denied")))))
+	ExpectThat(t, nil, Not(ErrorChainContains(Any())))
+	ExpectThat(t, "status 404", Not(ErrorChainContains(Any())))
+
+	r := &testReporter{}
+	joined := fmt.Errorf("retrying: %w", errors.Join(fmt.Errorf("attempt 1: %w", notFound), &statusError{503}))
+	ExpectThat(r, joined, ErrorChainContains(Field("Code", Lt(400))))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: is an error with an error in its chain which has field Code which is less than 400 (int)",
+		"  Got: retrying: attempt 1: status 404",
+		"status 503 (*fmt.wrapError)",
+		"  ...where no error in the chain matches:",
+		"    retrying: attempt 1: status 404",
+		"status 503 (*fmt.wrapError), where value (fmt.wrapError) has no field Code",
+		"    attempt 1: status 404",
+		"status 503 (*errors.joinError), where value (errors.joinError) has no field Code",
+		"      attempt 1: status 404 (*fmt.wrapError), where value (fmt.wrapError) has no field Code",
+		"      status 404 (*gotest.statusError), where field Code is 404 (int)",
+		"      status 503 (*gotest.statusError), where field Code is 503 (int)",
+	))
+
+	r.Reset()
+	ExpectThat(r, nil, ErrorChainContains(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where error is nil"))
+
+	r.Reset()
+	ExpectThat(r, "status 404", ErrorChainContains(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not an error"))
+}
+
+func TestErrorAsThat(t *testing.T) {
+	err := fmt.Errorf("fetching: %w", &statusError{404})
+	ExpectThat(t, err, ErrorAsThat[*statusError](Any()))
+	ExpectThat(t, err, ErrorAsThat[*statusError](Field("Code", 404)))
+	ExpectThat(t, err, Not(ErrorAsThat[*statusError](Field("Code", 500))))
+	ExpectThat(t, err, Not(ErrorAsThat[*customError](Any())))
+	ExpectThat(t, nil, Not(ErrorAsThat[*statusError](Any())))
+
+	r := &testReporter{}
+	ExpectThat(r, err, ErrorAsThat[*statusError](Field("Code", Ge(500))))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is an error which, as *gotest.statusError, has field Code which is greater than or equal to 500 (int)",
+		"  Got: fetching: status 404 (*fmt.wrapError)",
+		"  ...where error is status 404 (*gotest.statusError), where field Code is 404 (int)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, err, ErrorAsThat[*customError](Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(strings.Join([]string{
+		"  ...where no error in the chain is a *gotest.customError:",
+		"    fetching: status 404 (*fmt.wrapError)",
+		"    status 404 (*gotest.statusError)",
+	}, "\n")))
+}
+
+func TestExpectErrorAs(t *testing.T) {
+	err := fmt.Errorf("fetching: %w", &statusError{404})
+	statusErr, ok := ExpectErrorAs[*statusError](t, err)
+	ExpectThat(t, ok, true)
+	ExpectThat(t, statusErr, Field("Code", 404))
+	ExpectEq(t, AssertErrorAs[*statusError](t, err), statusErr)
+
+	r := &testReporter{}
+	customErr, ok := ExpectErrorAs[*customError](r, err)
+	ExpectThat(t, ok, false)
+	ExpectThat(t, customErr, Nil())
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("no error in the chain is a *gotest.customError")))
+
+	r.Reset()
+	AssertErrorAs[*customError](r, nil)
+	ExpectThat(t, r.fatals, ElementsAre(HasSubstr("...where error is nil")))
+}
@@ -2,6 +2,9 @@ package gotest
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
 
 	"go.uber.org/mock/gomock"
 )
@@ -15,6 +18,30 @@ type MismatchExplainer interface {
 	ExplainFailure(val any) (string, bool)
 }
 
+// Implemented by matchers that carry a user-provided message to be included
+// in failure output. See WithMessage().
+type messageAnnotator interface {
+	failureMessage() string
+}
+
+// Tests `val` against `matcher` (or a value, which is compared with Eq()), and
+// returns the explanation that ExpectThat() would report if it doesn't match,
+// or "" if it does. This allows matchers to be used outside of assertions,
+// such as in custom assertion helpers, or to validate values in other code.
+//
+// Example:
+//
+//	if ok, explanation := Explain(HasSubstr("ready"), status); !ok {
+//		log.Printf("not ready yet: %s", explanation)
+//	}
+func Explain(matcher any, val any) (ok bool, explanation string) {
+	m := AsMatcher(matcher)
+	if m.Matches(val) {
+		return true, ""
+	}
+	return false, getExplanation("Expectation", m, val)
+}
+
 func formatGot(val any, matcher Matcher) string {
 	if asFormatter, ok := matcher.(gomock.GotFormatter); ok {
 		return asFormatter.Got(val)
@@ -22,3 +49,112 @@ func formatGot(val any, matcher Matcher) string {
 		return fmt.Sprintf("%v (%[1]T)", val)
 	}
 }
+
+// Returns the matcher's explanation for why `val` doesn't match, or a generic
+// message if it doesn't provide one.
+func explainMismatch(matcher Matcher, val any) string {
+	if explainer, ok := matcher.(MismatchExplainer); ok {
+		if e, useE := explainer.ExplainFailure(val); useE {
+			return e
+		}
+	}
+	return "doesn't match"
+}
+
+// Appends the matcher's explanation for why `val` doesn't match to `s`, if it
+// provides one.
+func appendExplanation(s string, matcher Matcher, val any) string {
+	if explainer, ok := matcher.(MismatchExplainer); ok {
+		if e, useE := explainer.ExplainFailure(val); useE {
+			return s + ", where " + e
+		}
+	}
+	return s
+}
+
+// Returns the message attached to the matcher with WithMessage(), if any.
+func failureMessageOf(matcher Matcher) string {
+	if annotated, ok := matcher.(messageAnnotator); ok {
+		return annotated.failureMessage()
+	}
+	return ""
+}
+
+// Limits on the size of the Got value and the explanation printed when an
+// assertion fails, so that huge values don't flood the test log. Each is
+// limited separately; zero means no limit.
+type OutputLimits struct {
+	MaxLines int
+	MaxBytes int
+}
+
+// The limits used by assertions whose matcher doesn't set its own with
+// WithOutputLimits(). Setting the NoTruncationEnv environment variable, e.g.
+// in CI, disables truncation entirely.
+var DefaultOutputLimits = OutputLimits{MaxLines: 200, MaxBytes: 64 << 10}
+
+// Setting this environment variable to any non-empty value disables the
+// truncation of failure output.
+const NoTruncationEnv = "GOTEST_NO_TRUNCATION"
+
+// Implemented by matchers that override DefaultOutputLimits. See
+// WithOutputLimits().
+type outputLimiter interface {
+	outputLimits() OutputLimits
+}
+
+func outputLimitsOf(matcher Matcher) OutputLimits {
+	if os.Getenv(NoTruncationEnv) != "" {
+		return OutputLimits{}
+	}
+	if limits, ok := ownOutputLimits(matcher); ok {
+		return limits
+	}
+	return DefaultOutputLimits
+}
+
+// Returns the limits set by the matcher, or by the matcher that WithMessage()
+// wraps.
+func ownOutputLimits(matcher Matcher) (OutputLimits, bool) {
+	for {
+		switch m := matcher.(type) {
+		case outputLimiter:
+			return m.outputLimits(), true
+		case messageMatcher:
+			matcher = m.inner
+		default:
+			return OutputLimits{}, false
+		}
+	}
+}
+
+// Cuts `s` down to `limits`, marking how much was left out.
+func truncate(s string, limits OutputLimits) string {
+	kept := s
+	if limits.MaxLines > 0 {
+		if lines := strings.SplitAfter(kept, "\n"); len(lines) > limits.MaxLines {
+			kept = strings.Join(lines[:limits.MaxLines], "")
+		}
+	}
+	if limits.MaxBytes > 0 && len(kept) > limits.MaxBytes {
+		cut := limits.MaxBytes
+		for cut > 0 && !utf8.RuneStart(kept[cut]) {
+			cut--
+		}
+		// Cut between lines where possible, so the marker can count them.
+		i := strings.LastIndexByte(kept[:cut], '\n')
+		if i < 0 {
+			return fmt.Sprintf("%s\n... %d more bytes truncated", kept[:cut], len(s)-cut)
+		}
+		kept = kept[:i+1]
+	}
+	if len(kept) == len(s) {
+		return s
+	}
+	omitted := strings.SplitAfter(s[len(kept):], "\n")
+	if omitted[len(omitted)-1] == "" {
+		// The text ends with a newline, which doesn't start another line.
+		omitted = omitted[:len(omitted)-1]
+	}
+	return fmt.Sprintf("%s\n... %d more lines truncated", strings.TrimSuffix(kept, "\n"), len(omitted))
+}
@@ -0,0 +1,80 @@
+package gotest
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// The scaffolding that most tests using mocks need. See NewFixture().
+type Fixture struct {
+	// The controller for the test's mocks. Its expectations are verified when
+	// the test finishes.
+	Ctrl *gomock.Controller
+	// A context that's done when the test's deadline, if any, passes, or once
+	// the test has finished.
+	Ctx context.Context
+	// Reports failures of the test without terminating it, even those of
+	// assertions, so that the rest of the test still runs. Configure() applies
+	// to it, except for FailFast().
+	Soft gomock.TestHelper
+}
+
+// Returns the scaffolding for a test using mocks. The controller's
+// expectations are verified, and the context is canceled, when `t` finishes,
+// so neither needs to be done by the test.
+//
+// Example:
+//
+//	func TestClient(t *testing.T) {
+//		f := NewFixture(t)
+//		storage := mocks.NewMockStorage(f.Ctrl)
+//		storage.EXPECT().Save(StartsWith("user_"), gomock.Any()).Return(nil)
+//		AssertThat(f.Soft, NewClient(storage).Register(f.Ctx, "alice"), Nil())
+//		ExpectThat(f.Soft, ...)
+//	}
+func NewFixture(t interface {
+	gomock.TestHelper
+	Cleanup(func())
+}) *Fixture {
+	t.Helper()
+	// The controller registers its own cleanup to verify its expectations.
+	ctrl := gomock.NewController(t)
+	ctx, cancel := deadlineContext(t)
+	t.Cleanup(cancel)
+	return &Fixture{
+		Ctrl: ctrl,
+		Ctx:  ctx,
+		Soft: softReporter{t},
+	}
+}
+
+// Returns a context that's done at the deadline of `t`, if it has one.
+func deadlineContext(t any) (context.Context, context.CancelFunc) {
+	if d, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := d.Deadline(); ok {
+			return context.WithDeadline(context.Background(), deadline)
+		}
+	}
+	return context.WithCancel(context.Background())
+}
+
+// Reports fatal failures of the wrapped test as non-fatal ones.
+type softReporter struct {
+	t gomock.TestHelper
+}
+
+func (r softReporter) Errorf(format string, args ...any) {
+	r.t.Helper()
+	r.t.Errorf(format, args...)
+}
+
+func (r softReporter) Fatalf(format string, args ...any) {
+	r.t.Helper()
+	r.t.Errorf(format, args...)
+}
+
+func (r softReporter) Helper() {
+	r.t.Helper()
+}
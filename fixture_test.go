@@ -0,0 +1,63 @@
+package gotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+type deadlineReporter struct {
+	cleanupReporter
+	deadline time.Time
+}
+
+func (r *deadlineReporter) Deadline() (time.Time, bool) {
+	return r.deadline, true
+}
+
+func TestNewFixture(t *testing.T) {
+	r := &cleanupReporter{}
+	f := NewFixture(r)
+	_, hasDeadline := f.Ctx.Deadline()
+	ExpectEq(t, hasDeadline, false)
+	ExpectEq(t, f.Ctx.Err(), nil)
+
+	m := &mockSender{f.Ctrl}
+	m.expectSend("alice", "hi").Return(nil)
+	AssertThat(f.Soft, 2, Gt(3))
+	ExpectThat(f.Soft, 2, Gt(3))
+	ExpectEq(t, r.fatals, nil)
+	ExpectEq(t, len(r.nonFatals), 2)
+
+	// Finishing the test verifies the mocks and cancels the context.
+	r.Reset()
+	r.finish()
+	ExpectThat(t, f.Ctx.Err(), ErrorIs(context.Canceled))
+	ExpectThat(t, append(r.nonFatals, r.fatals...), Contains(HasSubstr("missing call(s) to *gotest.mockSender.Send(")))
+}
+
+func TestNewFixture_Deadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	r := &deadlineReporter{deadline: deadline}
+	defer r.finish()
+	f := NewFixture(r)
+	got, ok := f.Ctx.Deadline()
+	ExpectEq(t, ok, true)
+	ExpectEq(t, got, deadline)
+}
+
+func TestNewFixture_SoftIgnoresFailFast(t *testing.T) {
+	r := &cleanupReporter{}
+	defer r.finish()
+	Configure(r, FailFast(), UseColor(true))
+	f := NewFixture(r)
+	m := &mockSender{f.Ctrl}
+	m.expectSend(gomock.Any(), gomock.Any()).Return(nil)
+	m.Send("alice", "hi")
+
+	ExpectThat(f.Soft, []string{"a"}, []string{"b"})
+	ExpectEq(t, r.fatals, nil)
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr(ansiRed)))
+}
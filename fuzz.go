@@ -0,0 +1,76 @@
+package gotest
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Same as ExpectThat(), for use in the functions passed to f.Fuzz(): on
+// failure, `inputs`, the arguments that the fuzzer passed, are included in
+// the output. The fuzzer records the inputs that fail, but without them a
+// failure's explanation is hard to relate to its cause.
+//
+// Example:
+//
+//	f.Fuzz(func(t *testing.T, s string) {
+//		SkipUnless(t, utf8.ValidString(s), true)
+//		CheckThat(t, Reverse(Reverse(s)), s, s)
+//	})
+func CheckThat(t gomock.TestHelper, val any, matcher any, inputs ...any) bool {
+	t.Helper()
+	m := AsMatcher(matcher)
+	if len(inputs) > 0 {
+		m = WithMessage(m, "for fuzz input %s", formatFuzzInputs(inputs))
+	}
+	return ExpectThat(t, val, m)
+}
+
+// Formats fuzz inputs the way they're written in Go source, like the corpus
+// files of the fuzzer.
+func formatFuzzInputs(inputs []any) string {
+	formatted := make([]string, len(inputs))
+	for i, in := range inputs {
+		switch v := in.(type) {
+		case string:
+			formatted[i] = fmt.Sprintf("%q", v)
+		case []byte:
+			formatted[i] = fmt.Sprintf("[]byte(%q)", v)
+		default:
+			formatted[i] = fmt.Sprintf("%T(%#v)", v, v)
+		}
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+// A test that can be skipped, like *testing.T.
+type skipper interface {
+	gomock.TestHelper
+	SkipNow()
+}
+
+// Skips the rest of the test unless `val` fulfills `matcher`. In a fuzz test,
+// this tells the fuzzer that the input isn't interesting, so that it tries
+// others instead.
+//
+// Example:
+//
+//	f.Fuzz(func(t *testing.T, n int) {
+//		SkipIf(t, n, math.MinInt)
+//		CheckThat(t, Abs(n), Ge(0), n)
+//	})
+func SkipUnless(t skipper, val any, matcher any) {
+	t.Helper()
+	if !AsMatcher(matcher).Matches(val) {
+		t.SkipNow()
+	}
+}
+
+// Skips the rest of the test if `val` fulfills `matcher`. See SkipUnless().
+func SkipIf(t skipper, val any, matcher any) {
+	t.Helper()
+	if AsMatcher(matcher).Matches(val) {
+		t.SkipNow()
+	}
+}
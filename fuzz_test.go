@@ -0,0 +1,70 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// A testReporter that records whether the test was skipped.
+type skipReporter struct {
+	testReporter
+	skipped bool
+}
+
+func (r *skipReporter) SkipNow() {
+	r.skipped = true
+}
+
+func TestCheckThat(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, CheckThat(&r, 5, Gt(3), 5), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, CheckThat(&r, "ab", Len(3), "a", []byte("b\x00"), 7, int8(-1)), false)
+	ExpectEq(t, CheckThat(&r, 2, Gt(3)), false)
+	ExpectEq(t, r.nonFatals, []string{
+		strings.Join([]string{
+			"Expectation failed:",
+			"  Wanted: has length which is equal to 3 (int)",
+			"  Got: ab (string)",
+			`  Message: for fuzz input ("a", []byte("b\x00"), int(7), int8(-1))`,
+			"  ...where length is 2",
+		}, "\n"),
+		"Expectation failed:\n  Wanted: is greater than 3 (int)\n  Got: 2 (int)",
+	})
+}
+
+func TestSkipUnless(t *testing.T) {
+	r := &skipReporter{}
+	SkipUnless(r, 5, Gt(3))
+	SkipIf(r, 5, Lt(3))
+	ExpectEq(t, r.skipped, false)
+
+	SkipUnless(r, 2, Gt(3))
+	ExpectEq(t, r.skipped, true)
+
+	r = &skipReporter{}
+	SkipIf(r, "", Empty())
+	ExpectEq(t, r.skipped, true)
+	ExpectEq(t, r.HasFailures(), false)
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func FuzzReverse(f *testing.F) {
+	f.Add("hello")
+	f.Add("")
+	f.Add("\xff")
+	f.Fuzz(func(t *testing.T, s string) {
+		SkipUnless(t, utf8.ValidString(s), true)
+		CheckThat(t, reverse(reverse(s)), s, s)
+		CheckThat(t, reverse(s), Len(len(s)), s)
+	})
+}
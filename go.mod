@@ -6,4 +6,9 @@ require go.uber.org/mock v0.5.0
 
 require google.golang.org/protobuf v1.36.4
 
-require github.com/google/go-cmp v0.7.0
+require (
+	github.com/google/go-cmp v0.7.0
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
+)
+
+require golang.org/x/text v0.14.0
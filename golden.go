@@ -0,0 +1,263 @@
+package gotest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+// Setting this environment variable to any non-empty value rewrites golden
+// files, the same as running the test binary with -update.
+const UpdateGoldensEnv = "GOTEST_UPDATE_GOLDENS"
+
+// Tests that `val`, serialized as text, is the same as the contents of the
+// golden file at `path`. On failure, the differing lines are shown.
+//
+// By default, strings and byte slices are used as they are, protos are
+// compared with the prototext in the file as by MatchesProtoTextFile(), and
+// other values are pretty-printed as indented JSON. The format can be chosen
+// with GoldenText(), GoldenJSON(), or GoldenProtoText().
+//
+// If the test binary was run with -update, or the UpdateGoldensEnv environment
+// variable is set, the file (and its directory) is instead written with the
+// value, and the check succeeds. The flag isn't defined by this package; see
+// MatchesProtoTextFile().
+//
+// Examples:
+//
+//	ExpectMatchesGolden(t, render(page), "testdata/page.html.golden")
+//	ExpectMatchesGolden(t, resp, "testdata/response.json", GoldenJSON())
+func ExpectMatchesGolden(t gomock.TestHelper, val any, path string, opts ...GoldenOption) bool {
+	t.Helper()
+	return ExpectThat(t, val, MatchesGoldenFile(path, opts...))
+}
+
+// Matches values that are the same as the contents of the golden file at
+// `path`, when serialized as text. See ExpectMatchesGolden().
+//
+// Example:
+//
+//	ExpectThat(t, resp, Field("Body", MatchesGoldenFile("testdata/body.golden")))
+func MatchesGoldenFile(path string, opts ...GoldenOption) Matcher {
+	m := goldenMatcher{path: path}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// Configures ExpectMatchesGolden() and MatchesGoldenFile().
+type GoldenOption func(*goldenMatcher)
+
+type goldenFormat int
+
+const (
+	goldenDefault goldenFormat = iota
+	goldenText
+	goldenJSON
+	goldenProtoText
+)
+
+// Serializes values with fmt's %v verb, except for strings and byte slices,
+// which are used as they are. This isn't pretty-printed: a struct is written
+// on one line, like "{origin 0 0}". For one field per line, use the default
+// format or GoldenJSON().
+func GoldenText() GoldenOption {
+	return func(m *goldenMatcher) {
+		m.format = goldenText
+	}
+}
+
+// Serializes values as JSON, indented by two spaces.
+func GoldenJSON() GoldenOption {
+	return func(m *goldenMatcher) {
+		m.format = goldenJSON
+	}
+}
+
+// Compares protos with the prototext in the golden file, as by
+// MatchesProtoTextFile(), with the given ProtoOpts. Other values don't match.
+func GoldenProtoText(opts ...ProtoOpt) GoldenOption {
+	return func(m *goldenMatcher) {
+		m.format = goldenProtoText
+		m.protoOpts = opts
+	}
+}
+
+type goldenMatcher struct {
+	path      string
+	format    goldenFormat
+	protoOpts []ProtoOpt
+}
+
+// Returns the matcher that checks `x`, and the value to check with it: either
+// a proto matcher for `x` itself, or a text matcher for `x` serialized.
+func (m goldenMatcher) matcherFor(x any) (Matcher, any, error) {
+	_, isProto := x.(proto.Message)
+	if m.format == goldenProtoText || (m.format == goldenDefault && isProto) {
+		return MatchesProtoTextFile(m.path, m.protoOpts...), x, nil
+	}
+	got, err := m.serialize(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	if updateGoldens() {
+		if err := m.update(got); err != nil {
+			return nil, nil, fmt.Errorf("golden file can't be updated: %w", err)
+		}
+	}
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("golden file can't be read (run with -update to create it): %w", err)
+	}
+	return LinesEq(string(data)), got, nil
+}
+
+func (m goldenMatcher) serialize(x any) (string, error) {
+	if m.format == goldenText || m.format == goldenDefault {
+		switch v := x.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+	}
+	if m.format == goldenText {
+		return fmt.Sprintf("%v", x), nil
+	}
+	data, err := json.MarshalIndent(x, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("value can't be serialized as JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+func (m goldenMatcher) update(got string) error {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, []byte(got), 0o644)
+}
+
+func (m goldenMatcher) Matches(x any) bool {
+	matcher, got, err := m.matcherFor(x)
+	return err == nil && matcher.Matches(got)
+}
+
+func (m goldenMatcher) String() string {
+	return fmt.Sprintf("matches the golden file %s", m.path)
+}
+
+func (m goldenMatcher) ExplainFailure(x any) (string, bool) {
+	matcher, got, err := m.matcherFor(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	if matcher.Matches(got) {
+		return "", false
+	}
+	return explainMismatch(matcher, got), true
+}
+
+// Tests that `actual`, a string or byte slice, has the same contents as the
+// file at `path`. On failure, the differences are shown as a unified diff.
+//
+// Both texts are first passed through `normalizers`, in order, e.g. to ignore
+// details that change from run to run.
+//
+// Example:
+//
+//	ExpectEqFile(t, report.Render(), "testdata/report.txt", TrimTrailingSpace(), MaskTimestamps())
+func ExpectEqFile(t gomock.TestHelper, actual any, path string, normalizers ...Normalizer) bool {
+	t.Helper()
+	return ExpectThat(t, actual, EqFile(path, normalizers...))
+}
+
+// Matches strings and byte slices that have the same contents as the file at
+// `path`, after applying `normalizers`. See ExpectEqFile().
+//
+// Example:
+//
+//	ExpectThat(t, resp, Field("Body", EqFile("testdata/body.html")))
+func EqFile(path string, normalizers ...Normalizer) Matcher {
+	return fileMatcher{path: path, normalizers: normalizers}
+}
+
+// Rewrites text before it's compared by ExpectEqFile() or EqFile().
+type Normalizer func(string) string
+
+// Removes spaces and tabs at the ends of lines.
+func TrimTrailingSpace() Normalizer {
+	return Mask(`(?m)[ \t]+$`, "")
+}
+
+// Replaces timestamps such as "2024-05-01T12:30:00Z" and
+// "2024-05-01 12:30:00.123" with "<timestamp>".
+func MaskTimestamps() Normalizer {
+	return Mask(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`, "<timestamp>")
+}
+
+// Replaces matches of the regular expression `pattern` with `replacement`, as
+// by Regexp.ReplaceAllString().
+//
+// Example:
+//
+//	Mask(`request-id: \w+`, "request-id: <id>")
+func Mask(pattern, replacement string) Normalizer {
+	re := regexp.MustCompile(pattern)
+	return func(s string) string {
+		return re.ReplaceAllString(s, replacement)
+	}
+}
+
+type fileMatcher struct {
+	stringMatcher
+	path        string
+	normalizers []Normalizer
+}
+
+// Returns the normalized contents of the file, and of `x`.
+func (m fileMatcher) load(x any) (want, got string, err error) {
+	got, ok := m.getString(x)
+	if !ok {
+		explanation, _ := m.stringMatcher.ExplainFailure(x)
+		return "", "", errors.New(explanation)
+	}
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return "", "", fmt.Errorf("file can't be read: %w", err)
+	}
+	want = string(data)
+	for _, normalize := range m.normalizers {
+		want, got = normalize(want), normalize(got)
+	}
+	return want, got, nil
+}
+
+func (m fileMatcher) Matches(x any) bool {
+	want, got, err := m.load(x)
+	return err == nil && want == got
+}
+
+func (m fileMatcher) String() string {
+	return fmt.Sprintf("has the same contents as %s", m.path)
+}
+
+func (m fileMatcher) ExplainFailure(x any) (string, bool) {
+	want, got, err := m.load(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	if want == got {
+		return "", false
+	}
+	return "contents differ:\n" + formatUnifiedDiff(m.path, "got",
+		diffLines(strings.Split(want, "\n"), strings.Split(got, "\n"))), true
+}
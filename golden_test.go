@@ -0,0 +1,152 @@
+package gotest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfmatt/gotest/testdata"
+)
+
+type goldenPoint struct {
+	Name string
+	X, Y int
+}
+
+func TestExpectMatchesGolden(t *testing.T) {
+	r := &testReporter{}
+	ExpectEq(t, ExpectMatchesGolden(r, "hello,\nworld\n", "testdata/greeting.golden"), true)
+	ExpectEq(t, ExpectMatchesGolden(r, []byte("hello,\nworld\n"), "testdata/greeting.golden"), true)
+	ExpectEq(t, ExpectMatchesGolden(r, goldenPoint{Name: "origin"}, "testdata/point.golden.json"), true)
+	ExpectEq(t, ExpectMatchesGolden(r, &testdata.SomeData{A: "hello", I: 42, L: []string{"x", "y"}, Recursive: &testdata.SomeData{A: "nested"}},
+		"testdata/some_data.textproto"), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, ExpectMatchesGolden(r, "hello,\nmars\n", "testdata/greeting.golden"), false)
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches the golden file testdata/greeting.golden",
+		"  Got: hello,",
+		"mars",
+		" (string)",
+		"  ...where lines differ (-want +got):",
+		"       1 | hello,",
+		"  -    2 | world",
+		"  +    2 | mars",
+		"       3 | ",
+	}, "\n"))
+
+	r.Reset()
+	ExpectMatchesGolden(r, goldenPoint{Name: "origin", X: 1}, "testdata/point.golden.json")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("  -    3 |   \"X\": 0,\n  +    3 |   \"X\": 1,"))
+
+	r.Reset()
+	ExpectMatchesGolden(r, &testdata.SomeData{A: "hello"}, "testdata/some_data.textproto")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where doesn't match (-want +got):"))
+
+	r.Reset()
+	ExpectMatchesGolden(r, "hello", "testdata/missing.golden")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where golden file can't be read (run with -update to create it): open testdata/missing.golden: "))
+
+	r.Reset()
+	ExpectMatchesGolden(r, make(chan int), "testdata/greeting.golden")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value can't be serialized as JSON: "))
+}
+
+func TestMatchesGoldenFile_Formats(t *testing.T) {
+	ExpectThat(t, goldenPoint{Name: "origin"}, Not(MatchesGoldenFile("testdata/point.golden.json", GoldenText())))
+	ExpectThat(t, "hello,\nworld\n", Not(MatchesGoldenFile("testdata/greeting.golden", GoldenJSON())))
+	ExpectThat(t, "hello", Not(MatchesGoldenFile("testdata/some_data.textproto", GoldenProtoText())))
+	ExpectThat(t, &testdata.SomeData{A: "hello", I: 7, L: []string{"x", "y"}, Recursive: &testdata.SomeData{A: "nested"}},
+		MatchesGoldenFile("testdata/some_data.textproto", GoldenProtoText(IgnoreFields("i"))))
+}
+
+func TestExpectMatchesGolden_Update(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "new", "text.golden")
+	jsonPath := filepath.Join(dir, "point.json")
+	protoPath := filepath.Join(dir, "sub", "data.textproto")
+	msg := &testdata.SomeData{A: "hello", I: 7}
+	ExpectThat(t, "abc", Not(MatchesGoldenFile(textPath)))
+
+	*update = true
+	defer func() { *update = false }()
+	ExpectMatchesGolden(t, "abc", textPath)
+	*update = false
+	t.Setenv(UpdateGoldensEnv, "1")
+	ExpectMatchesGolden(t, goldenPoint{X: 3}, jsonPath, GoldenText())
+	ExpectMatchesGolden(t, msg, protoPath)
+	t.Setenv(UpdateGoldensEnv, "")
+
+	ExpectMatchesGolden(t, msg, protoPath)
+	ExpectThat(t, &testdata.SomeData{A: "bye", I: 7}, Not(MatchesGoldenFile(protoPath)))
+
+	ExpectMatchesGolden(t, "abc", textPath)
+	ExpectMatchesGolden(t, goldenPoint{X: 3}, jsonPath, GoldenText())
+	data, err := os.ReadFile(jsonPath)
+	AssertNoError(t, err)
+	ExpectEq(t, string(data), "{ 3 0}")
+}
+
+func TestExpectEqFile(t *testing.T) {
+	var lines []string
+	for i := 2; i <= 9; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	report := "Report generated at 2024-05-01T12:30:00Z\n" + strings.Join(lines, "\n") + "\ntotal: 10\n"
+	r := &testReporter{}
+	ExpectEq(t, ExpectEqFile(r, report, "testdata/report.txt"), true)
+	ExpectEq(t, ExpectEqFile(r, []byte(report), "testdata/report.txt"), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	changed := strings.Replace(report, "line 3", "line three", 1)
+	changed = strings.Replace(changed, "total: 10", "total: 11\nextra", 1)
+	ExpectEq(t, ExpectEqFile(r, changed, "testdata/report.txt"), false)
+	ExpectThat(t, r.nonFatals[0], HasSubstr(strings.Join([]string{
+		"  ...where contents differ:",
+		"--- testdata/report.txt",
+		"+++ got",
+		"@@ -1,5 +1,5 @@",
+		" Report generated at 2024-05-01T12:30:00Z",
+		" line 2",
+		"-line 3",
+		"+line three",
+		" line 4",
+		" line 5",
+		"@@ -8,4 +8,5 @@",
+		" line 8",
+		" line 9",
+		"-total: 10",
+		"+total: 11",
+		"+extra",
+		" ",
+	}, "\n")))
+
+	r.Reset()
+	ExpectEqFile(r, strings.Join(lines, "\n")+"\ntotal: 10\n", "testdata/report.txt")
+	ExpectThat(t, r.nonFatals[0], HasSubstr("+++ got\n@@ -1,3 +1,2 @@\n-Report generated"))
+
+	r.Reset()
+	ExpectEqFile(r, 5, "testdata/report.txt")
+	ExpectEqFile(r, "", "testdata/missing.txt")
+	ExpectThat(t, r.nonFatals, ElementsAre(
+		HasSubstr("...where value is of type int, not a string"),
+		HasSubstr("...where file can't be read: open testdata/missing.txt: "),
+	))
+}
+
+func TestEqFile_Normalizers(t *testing.T) {
+	report, err := os.ReadFile("testdata/report.txt")
+	AssertNoError(t, err)
+	rerun := strings.Replace(string(report), "2024-05-01T12:30:00Z", "2025-01-02 03:04:05.678+01:00", 1)
+	ExpectThat(t, rerun, Not(EqFile("testdata/report.txt")))
+	ExpectThat(t, rerun, EqFile("testdata/report.txt", MaskTimestamps()))
+
+	spaced := strings.ReplaceAll(string(report), "\n", " \t\n")
+	ExpectThat(t, spaced, Not(EqFile("testdata/report.txt")))
+	ExpectThat(t, spaced, EqFile("testdata/report.txt", TrimTrailingSpace()))
+
+	ExpectThat(t, strings.ReplaceAll(string(report), "line", "row"), EqFile("testdata/report.txt", Mask(`line|row`, "entry")))
+}
@@ -0,0 +1,23 @@
+module github.com/jfmatt/gotest/gomegaadapter
+
+go 1.23.1
+
+require (
+	github.com/jfmatt/gotest v0.0.0-20261018004104-31e6d4806e77
+	github.com/onsi/gomega v1.34.1
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2 // indirect
+	go.uber.org/mock v0.5.0 // indirect
+	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
+	golang.org/x/net v0.27.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/protobuf v1.36.4 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Builds against the root module in this repository. Replace directives only
+// apply within this module, so users get the version required above.
+replace github.com/jfmatt/gotest => ../
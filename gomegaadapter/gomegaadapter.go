@@ -0,0 +1,109 @@
+// Package gomegaadapter converts between gotest matchers and Gomega matchers,
+// so that either library's matchers can be used with the other's assertions:
+// e.g., Gomega's HTTP and async matchers inside ExpectThat(), or gotest's
+// matchers and failure explanations inside a Ginkgo suite.
+package gomegaadapter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jfmatt/gotest"
+	"github.com/onsi/gomega/types"
+)
+
+// Adapts a gotest matcher (or a value, which is compared with gotest.Eq()) to
+// a Gomega matcher. Failure messages are those of gotest.Explain().
+//
+// Example:
+//
+//	Expect(resp).To(ToGomega(gotest.Field("Status", gotest.HasSubstr("OK"))))
+func ToGomega(m any) types.GomegaMatcher {
+	return gomegaMatcher{gotest.AsMatcher(m)}
+}
+
+type gomegaMatcher struct {
+	inner gotest.Matcher
+}
+
+func (m gomegaMatcher) Match(actual any) (bool, error) {
+	return m.inner.Matches(actual), nil
+}
+
+func (m gomegaMatcher) FailureMessage(actual any) string {
+	_, explanation := gotest.Explain(m.inner, actual)
+	return explanation
+}
+
+func (m gomegaMatcher) NegatedFailureMessage(actual any) string {
+	_, explanation := gotest.Explain(gotest.Not(m.inner), actual)
+	return explanation
+}
+
+// Adapts a Gomega matcher to a gotest matcher. A value that makes the Gomega
+// matcher return an error doesn't match.
+//
+// Example:
+//
+//	ExpectThat(t, resp, FromGomega(ghttp.HaveHTTPStatus(http.StatusOK)))
+func FromGomega(m types.GomegaMatcher) gotest.Matcher {
+	return fromGomegaMatcher{m}
+}
+
+type fromGomegaMatcher struct {
+	inner types.GomegaMatcher
+}
+
+func (m fromGomegaMatcher) Matches(x any) bool {
+	ok, err := m.inner.Match(x)
+	return err == nil && ok
+}
+
+func (m fromGomegaMatcher) String() string {
+	return "satisfies " + describe(m.inner)
+}
+
+// Describes a Gomega matcher the way it's written, e.g. "BeNumerically(">", 3)",
+// from its type and exported fields. Gomega has no way for a matcher to
+// describe itself, other than a failure message for a value it has matched.
+func describe(m types.GomegaMatcher) string {
+	if s, ok := m.(fmt.Stringer); ok {
+		return s.String()
+	}
+	v := reflect.Indirect(reflect.ValueOf(m))
+	if v.Kind() != reflect.Struct {
+		return fmt.Sprintf("%T", m)
+	}
+	var args []string
+	for i := range v.NumField() {
+		if v.Type().Field(i).IsExported() && !v.Field(i).IsZero() {
+			args = append(args, describeArg(v.Field(i).Interface()))
+		}
+	}
+	return fmt.Sprintf("%s(%s)", strings.TrimSuffix(v.Type().Name(), "Matcher"), strings.Join(args, ", "))
+}
+
+func describeArg(x any) string {
+	switch x := x.(type) {
+	case types.GomegaMatcher:
+		return describe(x)
+	case string:
+		return fmt.Sprintf("%q", x)
+	}
+	if v := reflect.ValueOf(x); v.Kind() == reflect.Slice {
+		args := make([]string, v.Len())
+		for i := range v.Len() {
+			args[i] = describeArg(v.Index(i).Interface())
+		}
+		return strings.Join(args, ", ")
+	}
+	return fmt.Sprintf("%v", x)
+}
+
+func (m fromGomegaMatcher) ExplainFailure(x any) (string, bool) {
+	if _, err := m.inner.Match(x); err != nil {
+		return fmt.Sprintf("matcher failed: %s", err), true
+	}
+	return m.inner.FailureMessage(x), true
+}
@@ -0,0 +1,61 @@
+package gomegaadapter_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/jfmatt/gotest"
+	"github.com/jfmatt/gotest/gomegaadapter"
+	"github.com/onsi/gomega"
+)
+
+func TestToGomega(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect("hello").To(gomegaadapter.ToGomega(HasSubstr("ell")))
+	g.Expect("hello").NotTo(gomegaadapter.ToGomega(HasSubstr("xyz")))
+	g.Expect([]int{1, 2}).To(gomegaadapter.ToGomega([]int{1, 2}))
+
+	var failures []string
+	fake := gomega.NewGomega(func(message string, _ ...int) {
+		failures = append(failures, message)
+	})
+	fake.Expect(5).To(gomegaadapter.ToGomega(Lt(3)))
+	fake.Expect("hello").NotTo(gomegaadapter.ToGomega(HasSubstr("ell")))
+	fake.Expect(5).To(gomegaadapter.ToGomega(HasSubstr("ell")))
+	ExpectEq(t, failures, []string{
+		strings.Join([]string{
+			"Expectation failed:",
+			"  Wanted: is less than 3 (int)",
+			"  Got: 5 (int)",
+		}, "\n"),
+		strings.Join([]string{
+			"Expectation failed:",
+			"  Wanted: not(has substring 'ell')",
+			"  Got: hello (string)",
+		}, "\n"),
+		strings.Join([]string{
+			"Expectation failed:",
+			"  Wanted: has substring 'ell'",
+			"  Got: 5 (int)",
+			"  ...where value is of type int, not a string",
+		}, "\n"),
+	})
+}
+
+func TestFromGomega(t *testing.T) {
+	ExpectThat(t, []int{1, 2, 3}, gomegaadapter.FromGomega(gomega.HaveLen(3)))
+	ExpectThat(t, []int{1, 2}, Not(gomegaadapter.FromGomega(gomega.HaveLen(3))))
+	ExpectThat(t, 5, Not(gomegaadapter.FromGomega(gomega.HaveLen(3))))
+	ExpectThat(t, map[string]int{"a": 1}, HasValue(gomegaadapter.FromGomega(gomega.BeNumerically(">", 0))))
+
+	m := gomegaadapter.FromGomega(gomega.HaveLen(3))
+	ExpectEq(t, m.String(), "satisfies HaveLen(3)")
+	ExpectEq(t, gomegaadapter.FromGomega(gomega.BeNumerically(">", 0)).String(), `satisfies BeNumerically(">", 0)`)
+	ExpectEq(t, gomegaadapter.FromGomega(gomega.And(gomega.HaveLen(1), gomega.ContainElement("a"))).String(),
+		`satisfies And(HaveLen(1), ContainElement("a"))`)
+	ExpectEq(t, gomegaadapter.FromGomega(gomega.BeNil()).String(), "satisfies BeNil()")
+	explanation, _ := m.(MismatchExplainer).ExplainFailure([]int{1, 2})
+	ExpectThat(t, explanation, AllOf(HasSubstr("[1, 2]"), HasSubstr("to have length 3")))
+	explanation, _ = m.(MismatchExplainer).ExplainFailure(5)
+	ExpectThat(t, explanation, StartsWith("matcher failed: HaveLen matcher expects a string/array/map/channel/slice."))
+}
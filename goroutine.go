@@ -0,0 +1,94 @@
+package gotest
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Returns a reporter for assertions made by goroutines other than the test's
+// own. Failures are recorded, and reported to `t` when it finishes, so the
+// goroutines must be done by then, e.g. by waiting for them at the end of the
+// test.
+//
+// A fatal failure, such as that of an assertion, can't stop the test from
+// another goroutine. Instead, it's recorded, and the goroutine that made it
+// panics, so that it doesn't carry on with a value that's known to be bad.
+// If the goroutine doesn't recover, the panic ends the test binary, and its
+// message includes the failure.
+//
+// Example:
+//
+//	r := Go(t)
+//	var wg sync.WaitGroup
+//	for _, id := range ids {
+//		wg.Add(1)
+//		go func() {
+//			defer wg.Done()
+//			user := Unwrap(store.Get(id))(r)
+//			ExpectThat(r, user.ID, id)
+//		}()
+//	}
+//	wg.Wait()
+func Go(t interface {
+	gomock.TestHelper
+	Cleanup(func())
+}) gomock.TestHelper {
+	r := &goroutineReporter{t: t}
+	t.Cleanup(func() {
+		t.Helper()
+		r.flush()
+	})
+	return r
+}
+
+type goroutineReporter struct {
+	t gomock.TestHelper
+
+	mu       sync.Mutex
+	failures []string
+	flushed  bool
+}
+
+func (r *goroutineReporter) Errorf(format string, args ...any) {
+	r.record(fmt.Sprintf(format, args...))
+}
+
+func (r *goroutineReporter) Fatalf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	r.record(msg)
+	panic(goroutineFatal(msg))
+}
+
+// Does nothing, since the failures are reported from the test's cleanup
+// rather than where they were made.
+func (r *goroutineReporter) Helper() {}
+
+func (r *goroutineReporter) record(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.flushed {
+		panic(fmt.Sprintf("gotest: failure reported by a goroutine after its test finished: %s", msg))
+	}
+	r.failures = append(r.failures, msg)
+}
+
+// Reports the recorded failures to the test.
+func (r *goroutineReporter) flush() {
+	r.t.Helper()
+	r.mu.Lock()
+	failures := r.failures
+	r.failures, r.flushed = nil, true
+	r.mu.Unlock()
+	for _, msg := range failures {
+		r.t.Errorf("%s", msg)
+	}
+}
+
+// The value that a goroutine panics with after a fatal failure.
+type goroutineFatal string
+
+func (f goroutineFatal) Error() string {
+	return "fatal failure in goroutine: " + string(f)
+}
@@ -0,0 +1,59 @@
+package gotest
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGo(t *testing.T) {
+	r := &cleanupReporter{}
+	g := Go(r)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ExpectThat(g, i, Lt(5))
+		}()
+	}
+	wg.Wait()
+	ExpectEq(t, r.HasFailures(), false)
+
+	r.finish()
+	ExpectEq(t, r.fatals, nil)
+	ExpectEq(t, len(r.nonFatals), 5)
+	ExpectThat(t, r.nonFatals, Each(StartsWith("Expectation failed:\n  Wanted: is less than 5 (int)\n")))
+}
+
+func TestGo_Fatal(t *testing.T) {
+	r := &cleanupReporter{}
+	g := Go(r)
+	recovered := make(chan any)
+	go func() {
+		defer func() { recovered <- recover() }()
+		AssertThat(g, 2, Gt(3))
+		t.Error("AssertThat() returned after a fatal failure")
+	}()
+	p := <-recovered
+	ExpectThat(t, p, ErrorMessage(StartsWith("fatal failure in goroutine: Assertion failed:\n")))
+
+	r.finish()
+	ExpectEq(t, r.fatals, nil)
+	ExpectThat(t, r.nonFatals, ElementsAre(StartsWith("Assertion failed:\n")))
+
+	ExpectThat(t, func() { g.Errorf("late") }, PanicsWithValue(HasSubstr("after its test finished: late")))
+}
+
+func TestGo_UsesTestConfig(t *testing.T) {
+	r := &cleanupReporter{}
+	Configure(r, UseColor(true))
+	g := Go(r)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ExpectThat(g, []string{"a"}, []string{"b"})
+	}()
+	<-done
+	r.finish()
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr(ansiRed)))
+}
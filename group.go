@@ -0,0 +1,162 @@
+package gotest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// A group of assertions that are bound to a context. See WithContext().
+type G struct {
+	ctx context.Context
+	t   gomock.TestHelper
+}
+
+// Returns an assertion group whose checks stop waiting once `ctx` is done,
+// failing the test with the context's error, rather than blocking inside a
+// matcher or polling forever. This keeps tests that are bounded by a deadline
+// from hanging, e.g. on a channel that never delivers.
+//
+// A matcher that's still running when the context is done is abandoned, not
+// stopped, so it should have no effects on the test once it finishes.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	g := WithContext(ctx, t)
+//	g.ExpectThat(results, ReceivesWithin(time.Minute, "done"))
+//	g.Eventually(func() any { return server.Status() }, "healthy", 100*time.Millisecond)
+func WithContext(ctx context.Context, t gomock.TestHelper) *G {
+	return &G{ctx, t}
+}
+
+// Same as ExpectThat(), unless the context is done before the check finishes.
+// Then, the test fails with the context's error.
+func (g *G) ExpectThat(val any, expected any) bool {
+	g.t.Helper()
+	c := configFor(g.t)
+	matcher := c.configure(AsMatcher(expected))
+	r, finished := g.check("Expectation", matcher, func() any { return val })
+	if !finished {
+		c.fail(g.t, false, g.abortedExplanation("Expectation", matcher, val))
+		return false
+	}
+	if r.stopped {
+		return false
+	}
+	if !r.ok {
+		c.fail(g.t, false, r.explanation)
+	}
+	return r.ok
+}
+
+// Same as AssertThat(), unless the context is done before the check
+// finishes. Then, the test terminates with the context's error.
+func (g *G) AssertThat(val any, expected any) {
+	g.t.Helper()
+	c := configFor(g.t)
+	matcher := c.configure(AsMatcher(expected))
+	r, finished := g.check("Assertion", matcher, func() any { return val })
+	if !finished {
+		c.fail(g.t, true, g.abortedExplanation("Assertion", matcher, val))
+		return
+	}
+	if r.stopped {
+		return
+	}
+	if !r.ok {
+		c.fail(g.t, true, r.explanation)
+	}
+}
+
+// Calls `f` every `interval` until its result fulfills `expected`. If the
+// context is done first, causes the test to fail, explaining the last result
+// that didn't match.
+//
+// Returns whether a result matched. Without a deadline or cancellation on the
+// context, this keeps trying for as long as the test runs.
+//
+// Example:
+//
+//	g.Eventually(func() any { return len(queue.Pending()) }, 0, 10*time.Millisecond)
+func (g *G) Eventually(f func() any, expected any, interval time.Duration) bool {
+	g.t.Helper()
+	c := configFor(g.t)
+	matcher := c.configure(AsMatcher(expected))
+	var last *groupResult
+	attempts := 0
+	for {
+		r, finished := g.check("Last attempt", matcher, f)
+		if !finished {
+			break
+		}
+		if r.stopped {
+			return false
+		}
+		if r.ok {
+			return true
+		}
+		attempts++
+		last = &r
+		select {
+		case <-time.After(interval):
+		case <-g.ctx.Done():
+		}
+	}
+	if last == nil {
+		c.fail(g.t, false, fmt.Sprintf("Eventually aborted (%v) before any attempt finished:\n  Wanted: %s",
+			context.Cause(g.ctx), matcher.String()))
+	} else {
+		c.fail(g.t, false, fmt.Sprintf("Eventually aborted (%v) after %d attempts. %s",
+			context.Cause(g.ctx), attempts, last.explanation))
+	}
+	return false
+}
+
+type groupResult struct {
+	ok          bool
+	explanation string
+	// Whether the check ended the test, so there's nothing left to report.
+	stopped bool
+}
+
+// Tests the value returned by `get` against `matcher`, and explains any
+// failure. Returns false if the context is done first.
+//
+// A panic in the check is raised again on the test's goroutine, and a call to
+// t.FailNow() in it terminates the test.
+func (g *G) check(what string, matcher Matcher, get func() any) (groupResult, bool) {
+	g.t.Helper()
+	if g.ctx.Err() != nil {
+		return groupResult{}, false
+	}
+	var r groupResult
+	done := goGuarded(func() {
+		val := get()
+		r.ok = matcher.Matches(val)
+		if !r.ok {
+			r.explanation = getExplanation(what, matcher, val)
+		}
+	})
+	select {
+	case exit := <-done:
+		if exit.panicked || exit.goexit {
+			exit.reraise(g.t, what)
+			return groupResult{stopped: true}, true
+		}
+		return r, true
+	case <-g.ctx.Done():
+		return groupResult{}, false
+	}
+}
+
+// Explains a check that was abandoned when the context was done. The
+// abandoned matcher may still be reading `val`, which formatting it only reads
+// as well.
+func (g *G) abortedExplanation(what string, matcher Matcher, val any) string {
+	return fmt.Sprintf("%s aborted (%v):\n  Wanted: %s\n  Got: %s",
+		what, context.Cause(g.ctx), matcher.String(), truncate(formatGot(val, matcher), outputLimitsOf(matcher)))
+}
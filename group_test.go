@@ -0,0 +1,117 @@
+package gotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithContext(t *testing.T) {
+	r := testReporter{}
+	g := WithContext(context.Background(), &r)
+	ExpectEq(t, g.ExpectThat(5, Gt(3)), true)
+	g.AssertThat("abc", HasSubstr("b"))
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, g.ExpectThat(2, Gt(3)), false)
+	g.AssertThat(2, Gt(3))
+	failure := strings.Join([]string{
+		"  Wanted: is greater than 3 (int)",
+		"  Got: 2 (int)",
+	}, "\n")
+	ExpectEq(t, r.nonFatals, []string{"Expectation failed:\n" + failure})
+	ExpectEq(t, r.fatals, []string{"Assertion failed:\n" + failure})
+}
+
+func TestWithContext_Aborted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r := testReporter{}
+	g := WithContext(ctx, &r)
+	ch := make(chan string)
+	ExpectEq(t, g.ExpectThat(ch, ReceivesWithin(time.Hour, "done")), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(strings.Join([]string{
+		"Expectation aborted (context deadline exceeded):",
+		"  Wanted: receives a value within 1h0m0s which is equal to done (string)",
+		fmt.Sprintf("  Got: %v (chan string)", ch),
+	}, "\n")))
+
+	// Checks after the context is done fail without waiting.
+	r.Reset()
+	g.AssertThat(5, 5)
+	ExpectThat(t, r.fatals, ElementsAre(StartsWith("Assertion aborted (context deadline exceeded):\n")))
+
+	r.Reset()
+	cause := errors.New("shutting down")
+	ctx, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(cause)
+	WithContext(ctx, &r).ExpectThat(5, 5)
+	ExpectThat(t, r.nonFatals, ElementsAre(StartsWith("Expectation aborted (shutting down):\n")))
+
+	// The value is truncated as in other failures.
+	r.Reset()
+	WithContext(ctx, &r).ExpectThat("a\nb\nc", WithOutputLimits(Any(), OutputLimits{MaxLines: 1}))
+	ExpectThat(t, r.nonFatals, ElementsAre(strings.Join([]string{
+		"Expectation aborted (shutting down):",
+		"  Wanted: is anything",
+		"  Got: a",
+		"... 2 more lines truncated",
+	}, "\n")))
+}
+
+func TestEventually(t *testing.T) {
+	r := testReporter{}
+	g := WithContext(context.Background(), &r)
+	calls := 0
+	ExpectEq(t, g.Eventually(func() any {
+		calls++
+		return calls
+	}, 3, time.Millisecond), true)
+	ExpectEq(t, calls, 3)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	g = WithContext(ctx, &r)
+	ExpectEq(t, g.Eventually(func() any { return "starting" }, "healthy", time.Millisecond), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(AllOf(
+		ContainsRegex(`^Eventually aborted \(context deadline exceeded\) after \d+ attempts. Last attempt failed:\n`),
+		HasSubstr("  Wanted: is equal to healthy (string)\n  Got: starting (string)"),
+	)))
+
+	r.Reset()
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	block := make(chan struct{})
+	defer close(block)
+	WithContext(ctx, &r).Eventually(func() any {
+		<-block
+		return nil
+	}, Nil(), time.Millisecond)
+	ExpectEq(t, r.nonFatals, []string{
+		"Eventually aborted (context deadline exceeded) before any attempt finished:\n  Wanted: is nil",
+	})
+}
+
+func TestWithContext_PanicsAndGoexit(t *testing.T) {
+	r := testReporter{}
+	g := WithContext(context.Background(), &r)
+	boom := MatcherFunc("boom", func(any) bool { panic("boom") })
+	ExpectThat(t, func() { g.ExpectThat(5, boom) }, PanicsWithValue("boom"))
+	ExpectThat(t, func() { g.AssertThat(5, boom) }, PanicsWithValue("boom"))
+	ExpectEq(t, r.HasFailures(), false)
+
+	// Without a deadline, this would wait forever for the attempt.
+	ExpectEq(t, g.Eventually(func() any {
+		runtime.Goexit()
+		return nil
+	}, Nil(), time.Millisecond), false)
+	ExpectEq(t, r.nonFatals, nil)
+	ExpectEq(t, r.fatals, []string{
+		"Last attempt stopped: runtime.Goexit() was called, e.g. by t.FailNow() on another goroutine",
+	})
+}
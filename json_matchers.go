@@ -0,0 +1,239 @@
+package gotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Matches strings and byte-arrays containing JSON that is structurally equal
+// to `expected`. Key order and insignificant whitespace are ignored, and
+// numbers are compared by value (so 1 and 1.0 are equal).
+//
+// Panics if `expected` isn't valid JSON.
+//
+// Examples:
+//
+//	ExpectThat(t, `{"a": 1, "b": [1, 2]}`, JSONEq(`{"b":[1,2],"a":1}`))
+//	ExpectThat(t, resp.Body, JSONEq(`{"status": "ok"}`))
+//	ExpectThat(t, `[1, 2]`, Not(JSONEq(`[2, 1]`)))
+func JSONEq(expected string) Matcher {
+	var parsed any
+	if err := json.Unmarshal([]byte(expected), &parsed); err != nil {
+		panic(fmt.Sprintf("JSONEq: expected value isn't valid JSON: %s", err))
+	}
+	return jsonEqMatcher{expected, parsed}
+}
+
+// Matches strings and byte-arrays containing JSON which, when parsed,
+// fulfills `inner`.
+//
+// JSON is parsed into the same types as json.Unmarshal uses for `any`:
+// objects become map[string]any, arrays become []any, and numbers become
+// float64.
+//
+// Examples:
+//
+//	ExpectThat(t, `{"id": 5, "tags": ["a"]}`, JSONMatches(MapContains(map[string]any{
+//		"id":   Gt(0),
+//		"tags": Contains("a"),
+//	})))
+//	ExpectThat(t, `[1, 2, 3]`, JSONMatches(Len(3)))
+func JSONMatches(inner any) Matcher {
+	return jsonMatcher{AsMatcher(inner)}
+}
+
+type jsonEqMatcher struct {
+	expected string
+	parsed   any
+}
+
+func (m jsonEqMatcher) Matches(x any) bool {
+	parsed, err := parseJSON(x)
+	if err != nil {
+		return false
+	}
+	return cmp.Equal(parsed, m.parsed)
+}
+
+func (m jsonEqMatcher) String() string {
+	return fmt.Sprintf("is JSON equivalent to %s", m.expected)
+}
+
+func (m jsonEqMatcher) ExplainFailure(x any) (string, bool) {
+	parsed, err := parseJSON(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	diff := cmp.Diff(m.parsed, parsed)
+	if diff == "" {
+		return "", false
+	}
+	return fmt.Sprintf("JSON doesn't match (-want +got):\n%s", diff), true
+}
+
+type jsonMatcher struct {
+	inner Matcher
+}
+
+func (m jsonMatcher) Matches(x any) bool {
+	parsed, err := parseJSON(x)
+	if err != nil {
+		return false
+	}
+	return m.inner.Matches(parsed)
+}
+
+func (m jsonMatcher) String() string {
+	return fmt.Sprintf("is JSON which %s", m.inner.String())
+}
+
+func (m jsonMatcher) ExplainFailure(x any) (string, bool) {
+	parsed, err := parseJSON(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	explanation := fmt.Sprintf("parsed JSON is %s", formatGot(parsed, m.inner))
+	explanation = appendExplanation(explanation, m.inner, parsed)
+	return explanation, true
+}
+
+// Parses a string, []byte, or json.RawMessage containing JSON.
+func parseJSON(x any) (any, error) {
+	var data []byte
+	switch v := x.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		return nil, fmt.Errorf("value is of type %T, not a string", x)
+	}
+
+	var parsed any
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("value isn't valid JSON: %w", err)
+	}
+	return parsed, nil
+}
+
+// Matches values that validate against the JSON Schema `schema`. Strings and
+// byte-arrays are parsed as JSON; any other value (e.g. a struct) is first
+// marshaled with encoding/json, so field tags are respected.
+//
+// On failure, each individual validation error is reported along with its
+// location in the document.
+//
+// Panics if `schema` isn't a valid JSON Schema.
+//
+// Examples:
+//
+//	ExpectThat(t, resp.Body, MatchesJSONSchema(`{
+//		"type": "object",
+//		"required": ["id", "name"],
+//		"properties": {"id": {"type": "integer", "minimum": 1}}
+//	}`))
+//	ExpectThat(t, User{Name: "Alice"}, MatchesJSONSchema(userSchema))
+func MatchesJSONSchema(schema string) Matcher {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		panic(fmt.Sprintf("MatchesJSONSchema: schema isn't valid JSON: %s", err))
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", doc); err != nil {
+		panic(fmt.Sprintf("MatchesJSONSchema: invalid schema: %s", err))
+	}
+	compiled, err := c.Compile("schema.json")
+	if err != nil {
+		panic(fmt.Sprintf("MatchesJSONSchema: invalid schema: %s", err))
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(schema)); err != nil {
+		panic(fmt.Sprintf("MatchesJSONSchema: schema isn't valid JSON: %s", err))
+	}
+	return jsonSchemaMatcher{compact.String(), compiled}
+}
+
+type jsonSchemaMatcher struct {
+	desc   string
+	schema *jsonschema.Schema
+}
+
+func (m jsonSchemaMatcher) Matches(x any) bool {
+	doc, err := toJSONDocument(x)
+	if err != nil {
+		return false
+	}
+	return m.schema.Validate(doc) == nil
+}
+
+func (m jsonSchemaMatcher) String() string {
+	return fmt.Sprintf("matches JSON schema %s", m.desc)
+}
+
+func (m jsonSchemaMatcher) ExplainFailure(x any) (string, bool) {
+	doc, err := toJSONDocument(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	err = m.schema.Validate(doc)
+	if err == nil {
+		return "", false
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err.Error(), true
+	}
+	var problems []string
+	collectSchemaErrors(validationErr, &problems)
+	// Sort by location, since the validator visits properties in map order.
+	slices.Sort(problems)
+	return "schema validation failed " + strings.Join(problems, "; "), true
+}
+
+// Appends the leaves of a tree of validation errors, which are the ones that
+// describe what's actually wrong with the document.
+func collectSchemaErrors(err *jsonschema.ValidationError, problems *[]string) {
+	if len(err.Causes) == 0 {
+		*problems = append(*problems, err.Error())
+		return
+	}
+	for _, cause := range err.Causes {
+		collectSchemaErrors(cause, problems)
+	}
+}
+
+// Converts a value to a generic JSON document, parsing strings and
+// byte-arrays and marshaling anything else. Numbers are kept as json.Number
+// so that no precision is lost.
+func toJSONDocument(x any) (any, error) {
+	var data []byte
+	switch v := x.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(x); err != nil {
+			return nil, fmt.Errorf("value can't be marshaled to JSON: %w", err)
+		}
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("value isn't valid JSON: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,124 @@
+package gotest
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONEq(t *testing.T) {
+	ExpectThat(t, `{"a": 1, "b": [1, 2]}`, JSONEq(`{"b":[1,2],"a":1}`))
+	ExpectThat(t, "{\n  \"a\": 1.0\n}", JSONEq(`{"a":1}`))
+	ExpectThat(t, []byte(`"x"`), JSONEq(`"x"`))
+	ExpectThat(t, json.RawMessage(`null`), JSONEq(`null`))
+	ExpectThat(t, `[1, 2]`, Not(JSONEq(`[2, 1]`)))
+	ExpectThat(t, `{"a": 1}`, Not(JSONEq(`{"a": 1, "b": 2}`)))
+	ExpectThat(t, `{"a": 1}`, Not(JSONEq(`{"a": "1"}`)))
+	ExpectThat(t, `{"a": `, Not(JSONEq(`{}`)))
+	ExpectThat(t, 12, Not(JSONEq(`12`)))
+
+	ExpectFatal(t, HasSubstr("JSONEq: expected value isn't valid JSON"), func() {
+		JSONEq(`{"a": `)
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, `{"a": 1, "b": 2}`, JSONEq(`{"a": 1, "b": 3}`))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), Contains(
+		"Expectation failed:",
+		`  Wanted: is JSON equivalent to {"a": 1, "b": 3}`,
+		`  Got: {"a": 1, "b": 2} (string)`,
+		"  ...where JSON doesn't match (-want +got):",
+		HasSubstr(`"b": float64(3)`),
+		HasSubstr(`"b": float64(2)`),
+	))
+
+	r.Reset()
+	ExpectThat(&r, `{"a": `, JSONEq(`{}`))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value isn't valid JSON: unexpected end of JSON input"))
+
+	r.Reset()
+	ExpectThat(&r, 12, JSONEq(`12`))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+}
+
+func TestJSONMatches(t *testing.T) {
+	ExpectThat(t, `{"id": 5, "tags": ["a"]}`, JSONMatches(MapContains(map[string]any{
+		"id":   Gt(0),
+		"tags": Contains("a"),
+	})))
+	ExpectThat(t, `[1, 2, 3]`, JSONMatches(Len(3)))
+	ExpectThat(t, `"hello"`, JSONMatches(HasSubstr("ell")))
+	ExpectThat(t, `[1, 2, 3]`, Not(JSONMatches(Len(2))))
+	ExpectThat(t, `[1, 2`, Not(JSONMatches(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, `[1, 2, 3]`, JSONMatches(Len(2)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is JSON which has length which is equal to 2 (int)",
+		"  Got: [1, 2, 3] (string)",
+		"  ...where parsed JSON is [1 2 3] ([]interface {}), where length is 3",
+	}, "\n"))
+}
+
+type schemaUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func TestMatchesJSONSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string"}
+		}
+	}`
+	ExpectThat(t, `{"id": 5, "name": "Alice"}`, MatchesJSONSchema(schema))
+	ExpectThat(t, []byte(`{"id": 5, "name": "Alice", "extra": true}`), MatchesJSONSchema(schema))
+	ExpectThat(t, schemaUser{5, "Alice"}, MatchesJSONSchema(schema))
+	ExpectThat(t, &schemaUser{5, "Alice"}, MatchesJSONSchema(schema))
+	ExpectThat(t, `{"id": 0, "name": "Alice"}`, Not(MatchesJSONSchema(schema)))
+	ExpectThat(t, `{"id": 5}`, Not(MatchesJSONSchema(schema)))
+	ExpectThat(t, schemaUser{ID: 5}, Not(MatchesJSONSchema(schema)))
+	ExpectThat(t, `[1, 2]`, Not(MatchesJSONSchema(schema)))
+	ExpectThat(t, `{"id": `, Not(MatchesJSONSchema(schema)))
+	ExpectThat(t, make(chan int), Not(MatchesJSONSchema(schema)))
+
+	ExpectFatal(t, HasSubstr("MatchesJSONSchema: schema isn't valid JSON"), func() {
+		MatchesJSONSchema(`{"type": `)
+	})
+	ExpectFatal(t, HasSubstr("MatchesJSONSchema: invalid schema"), func() {
+		MatchesJSONSchema(`{"type": 12}`)
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, `{"id": 0, "name": 12}`, MatchesJSONSchema(`{"properties": {"id": {"minimum": 1}}}`))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		`  Wanted: matches JSON schema {"properties":{"id":{"minimum":1}}}`,
+		`  Got: {"id": 0, "name": 12} (string)`,
+		"  ...where schema validation failed at '/id': minimum: got 0, want 1",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, schemaUser{ID: 0}, MatchesJSONSchema(schema))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where schema validation failed "+
+		"at '': missing property 'name'; "+
+		"at '/id': minimum: got 0, want 1"))
+
+	r.Reset()
+	ExpectThat(&r, `{"id": 0, "name": 12}`, MatchesJSONSchema(schema))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where schema validation failed "+
+		"at '/id': minimum: got 0, want 1; "+
+		"at '/name': got number, want string"))
+
+	r.Reset()
+	ExpectThat(&r, `{"id": `, MatchesJSONSchema(schema))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value isn't valid JSON: unexpected EOF"))
+
+	r.Reset()
+	ExpectThat(&r, make(chan int), MatchesJSONSchema(schema))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value can't be marshaled to JSON: json: unsupported type: chan int"))
+}
@@ -0,0 +1,191 @@
+package gotest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matches values that fulfill all of `matchers`. Each element of `matchers`
+// can be either a matcher or an exact value.
+//
+// Unlike gomock.All(), failures report which of the matchers weren't
+// satisfied, and why.
+//
+// Examples:
+//
+//	ExpectThat(t, "hello", AllOf(StartsWith("h"), Len(5)))
+//	ExpectThat(t, 5, AllOf(Gt(0), Lt(10), Not(3)))
+//	ExpectThat(t, 5, Not(AllOf(Gt(0), Lt(3))))
+func AllOf(matchers ...any) Matcher {
+	return allOfMatcher{asMatchers(matchers)}
+}
+
+// Matches values that fulfill at least one of `matchers`. Each element of
+// `matchers` can be either a matcher or an exact value.
+//
+// Failures report why each of the matchers wasn't satisfied.
+//
+// Examples:
+//
+//	ExpectThat(t, "hello", AnyOf("hello", "goodbye"))
+//	ExpectThat(t, 5, AnyOf(Lt(0), Gt(3)))
+//	ExpectThat(t, 5, Not(AnyOf(Lt(0), Gt(10))))
+func AnyOf(matchers ...any) Matcher {
+	return anyOfMatcher{asMatchers(matchers)}
+}
+
+// Matches values that fulfill exactly one of `matchers`. Each element of
+// `matchers` can be either a matcher or an exact value.
+//
+// Useful for mutually-exclusive conditions. Failures report which matchers
+// matched, if more than one did.
+//
+// Examples:
+//
+//	ExpectThat(t, resp, ExactlyOneOf(
+//		Field("Result", Not(Nil())),
+//		Field("Error", Not(Nil())),
+//	))
+//	ExpectThat(t, 5, ExactlyOneOf(Lt(10), Gt(10)))
+//	ExpectThat(t, 5, Not(ExactlyOneOf(Lt(10), Gt(0))))
+func ExactlyOneOf(matchers ...any) Matcher {
+	return countOfMatcher{asMatchers(matchers), 1}
+}
+
+// Matches values that fulfill none of `matchers`. Each element of `matchers`
+// can be either a matcher or an exact value.
+//
+// This is equivalent to Not(AnyOf(matchers...)), but failures report which
+// matchers unexpectedly matched.
+//
+// Examples:
+//
+//	ExpectThat(t, "hello", NoneOf(HasSubstr("x"), Len(3)))
+//	ExpectThat(t, 5, Not(NoneOf(1, 3, 5)))
+func NoneOf(matchers ...any) Matcher {
+	return countOfMatcher{asMatchers(matchers), 0}
+}
+
+type allOfMatcher struct {
+	matchers []Matcher
+}
+
+func (m allOfMatcher) Matches(x any) bool {
+	for _, matcher := range m.matchers {
+		if !matcher.Matches(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m allOfMatcher) String() string {
+	return fmt.Sprintf("matches all of [%s]", joinMatcherStrings(m.matchers))
+}
+
+func (m allOfMatcher) ExplainFailure(x any) (string, bool) {
+	parts := make([]string, 0)
+	for i, matcher := range m.matchers {
+		if !matcher.Matches(x) {
+			parts = append(parts, fmt.Sprintf("matcher %d: %s", i, explainMismatch(matcher, x)))
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "; "), true
+}
+
+type anyOfMatcher struct {
+	matchers []Matcher
+}
+
+func (m anyOfMatcher) Matches(x any) bool {
+	for _, matcher := range m.matchers {
+		if matcher.Matches(x) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m anyOfMatcher) String() string {
+	return fmt.Sprintf("matches any of [%s]", joinMatcherStrings(m.matchers))
+}
+
+func (m anyOfMatcher) ExplainFailure(x any) (string, bool) {
+	if len(m.matchers) == 0 {
+		return "there are no matchers to satisfy", true
+	}
+	parts := make([]string, len(m.matchers))
+	for i, matcher := range m.matchers {
+		parts[i] = fmt.Sprintf("matcher %d: %s", i, explainMismatch(matcher, x))
+	}
+	return "no matchers matched; " + strings.Join(parts, "; "), true
+}
+
+func asMatchers(xs []any) []Matcher {
+	matchers := make([]Matcher, len(xs))
+	for i, x := range xs {
+		matchers[i] = AsMatcher(x)
+	}
+	return matchers
+}
+
+func joinMatcherStrings(matchers []Matcher) string {
+	parts := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		parts[i] = matcher.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Matches values that fulfill exactly `want` of `matchers`.
+type countOfMatcher struct {
+	matchers []Matcher
+	want     int
+}
+
+func (m countOfMatcher) matching(x any) []int {
+	matched := make([]int, 0)
+	for i, matcher := range m.matchers {
+		if matcher.Matches(x) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
+func (m countOfMatcher) Matches(x any) bool {
+	return len(m.matching(x)) == m.want
+}
+
+func (m countOfMatcher) String() string {
+	var prefix string
+	switch m.want {
+	case 0:
+		prefix = "matches none of"
+	case 1:
+		prefix = "matches exactly one of"
+	default:
+		prefix = fmt.Sprintf("matches exactly %d of", m.want)
+	}
+	return fmt.Sprintf("%s [%s]", prefix, joinMatcherStrings(m.matchers))
+}
+
+func (m countOfMatcher) ExplainFailure(x any) (string, bool) {
+	matched := m.matching(x)
+	if len(matched) == m.want {
+		return "", false
+	}
+	if len(matched) == 0 {
+		return "no matchers matched", true
+	} else if len(matched) == 1 {
+		return fmt.Sprintf("matcher %d matched", matched[0]), true
+	}
+	parts := make([]string, len(matched))
+	for i, idx := range matched {
+		parts[i] = fmt.Sprintf("%d", idx)
+	}
+	return fmt.Sprintf("%d matchers matched (%s)", len(matched), strings.Join(parts, ", ")), true
+}
@@ -0,0 +1,100 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAllOf(t *testing.T) {
+	ExpectThat(t, 5, AllOf())
+	ExpectThat(t, "hello", AllOf(StartsWith("h"), Len(5)))
+	ExpectThat(t, 5, AllOf(Gt(0), Lt(10), Not(3)))
+	ExpectThat(t, 5, AllOf(5))
+	ExpectThat(t, 5, Not(AllOf(Gt(0), Lt(3))))
+	ExpectThat(t, 5, Not(AllOf(4)))
+
+	r := testReporter{}
+	ExpectThat(&r, "hello", AllOf(StartsWith("h"), Len(3), HasSubstr("x")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches all of [" +
+			"starts with 'h'; " +
+			"has length which is equal to 3 (int); " +
+			"has substring 'x']",
+		"  Got: hello (string)",
+		"  ...where matcher 1: length is 5; matcher 2: doesn't match",
+	}, "\n"))
+}
+
+func TestAnyOf(t *testing.T) {
+	ExpectThat(t, 5, Not(AnyOf()))
+	ExpectThat(t, "hello", AnyOf("hello", "goodbye"))
+	ExpectThat(t, 5, AnyOf(Lt(0), Gt(3)))
+	ExpectThat(t, 5, Not(AnyOf(Lt(0), Gt(10))))
+
+	r := testReporter{}
+	ExpectThat(&r, "hello", AnyOf(Len(3), HasSubstr("x")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches any of [" +
+			"has length which is equal to 3 (int); " +
+			"has substring 'x']",
+		"  Got: hello (string)",
+		"  ...where no matchers matched; matcher 0: length is 5; matcher 1: doesn't match",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "hello", AnyOf())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches any of []",
+		"  Got: hello (string)",
+		"  ...where there are no matchers to satisfy",
+	}, "\n"))
+}
+
+func TestExactlyOneOf(t *testing.T) {
+	ExpectThat(t, 5, Not(ExactlyOneOf()))
+	ExpectThat(t, 5, ExactlyOneOf(Lt(10), Gt(10)))
+	ExpectThat(t, 5, ExactlyOneOf(5, 6, 7))
+	ExpectThat(t, 5, Not(ExactlyOneOf(Lt(10), Gt(0))))
+	ExpectThat(t, 5, Not(ExactlyOneOf(Lt(0), Gt(10))))
+
+	r := testReporter{}
+	ExpectThat(&r, 5, ExactlyOneOf(Lt(10), Gt(10), Gt(0)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches exactly one of [" +
+			"is less than 10 (int); " +
+			"is greater than 10 (int); " +
+			"is greater than 0 (int)]",
+		"  Got: 5 (int)",
+		"  ...where 2 matchers matched (0, 2)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 5, ExactlyOneOf(Gt(10)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no matchers matched"))
+}
+
+func TestNoneOf(t *testing.T) {
+	ExpectThat(t, 5, NoneOf())
+	ExpectThat(t, "hello", NoneOf(HasSubstr("x"), Len(3)))
+	ExpectThat(t, 5, Not(NoneOf(1, 3, 5)))
+
+	r := testReporter{}
+	ExpectThat(&r, 5, NoneOf(1, 5, Gt(3)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches none of [" +
+			"is equal to 1 (int); " +
+			"is equal to 5 (int); " +
+			"is greater than 3 (int)]",
+		"  Got: 5 (int)",
+		"  ...where 2 matchers matched (1, 2)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 5, NoneOf(1, 5))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where matcher 1 matched"))
+}
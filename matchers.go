@@ -1,6 +1,9 @@
 package gotest
 
 import (
+	"fmt"
+	"reflect"
+
 	"go.uber.org/mock/gomock"
 )
 
@@ -42,6 +45,67 @@ func AssignableToTypeOf(x any) Matcher {
 	return gomock.AssignableToTypeOf(x)
 }
 
+// Matches values whose dynamic type is assignable to T. For concrete types,
+// this means the value must be of exactly type T; for interface types, the
+// value must implement the interface.
+//
+// Unlike AssignableToTypeOf(), this doesn't require an exemplar value, and
+// failures report the actual dynamic type.
+//
+// Examples:
+//
+//	ExpectThat(t, 5, IsA[int]())
+//	ExpectThat(t, errors.New("x"), IsA[error]())
+//	ExpectThat(t, &bytes.Buffer{}, IsA[io.Reader]())
+//	ExpectThat(t, int32(5), Not(IsA[int]()))
+func IsA[T any]() Matcher {
+	return typeMatcher{reflect.TypeFor[T](), false}
+}
+
+// Matches values whose dynamic type is exactly `t`.
+//
+// Unlike IsA(), values never match an interface type, since dynamic types are
+// always concrete.
+//
+// Examples:
+//
+//	ExpectThat(t, 5, TypeIs(reflect.TypeOf(0)))
+//	ExpectThat(t, Name("x"), Not(TypeIs(reflect.TypeOf(""))))
+func TypeIs(t reflect.Type) Matcher {
+	return typeMatcher{t, true}
+}
+
+type typeMatcher struct {
+	t     reflect.Type
+	exact bool
+}
+
+func (m typeMatcher) Matches(x any) bool {
+	if x == nil {
+		return false
+	}
+	if m.exact {
+		return reflect.TypeOf(x) == m.t
+	}
+	return reflect.TypeOf(x).AssignableTo(m.t)
+}
+
+func (m typeMatcher) String() string {
+	if m.exact {
+		return fmt.Sprintf("has type %s", m.t)
+	} else if m.t.Kind() == reflect.Interface {
+		return fmt.Sprintf("implements %s", m.t)
+	}
+	return fmt.Sprintf("is a %s", m.t)
+}
+
+func (m typeMatcher) ExplainFailure(x any) (string, bool) {
+	if x == nil {
+		return "value is nil, with no dynamic type", true
+	}
+	return fmt.Sprintf("dynamic type is %T", x), true
+}
+
 // Nil returns a matcher that matches if the received value is nil.
 //
 // Example usage:
@@ -73,3 +137,163 @@ func Nil() Matcher {
 func Not(x any) Matcher {
 	return gomock.Not(AsMatcher(x))
 }
+
+// Creates a one-off matcher from a predicate function. The description is
+// used in failure output, in place of gomock.Cond's generic "adheres to a
+// custom condition".
+//
+// Examples:
+//
+//	isEven := MatcherFunc("is even", func(x any) bool {
+//		i, ok := x.(int)
+//		return ok && i%2 == 0
+//	})
+//	ExpectThat(t, 4, isEven)
+//	ExpectThat(t, []int{2, 4}, ElementsAre(isEven, isEven))
+func MatcherFunc(desc string, f func(any) bool) Matcher {
+	return funcMatcher{desc, f}
+}
+
+type funcMatcher struct {
+	desc string
+	f    func(any) bool
+}
+
+func (m funcMatcher) Matches(x any) bool {
+	return m.f(x)
+}
+
+func (m funcMatcher) String() string {
+	return m.desc
+}
+
+// Overrides the description of a matcher in failure output, while preserving
+// its behavior and any further explanation it provides. Useful for making
+// deeply nested compound matchers readable.
+//
+// Example:
+//
+//	ExpectThat(t, user, Named("valid user record", MapContains(map[string]any{
+//		"id":    Gt(0),
+//		"email": HasSubstr("@"),
+//	})))
+func Named(name string, m any) Matcher {
+	return namedMatcher{name, AsMatcher(m)}
+}
+
+type namedMatcher struct {
+	name  string
+	inner Matcher
+}
+
+func (m namedMatcher) Matches(x any) bool {
+	return m.inner.Matches(x)
+}
+
+func (m namedMatcher) String() string {
+	return m.name
+}
+
+func (m namedMatcher) ExplainFailure(x any) (string, bool) {
+	if explainer, ok := m.inner.(MismatchExplainer); ok {
+		return explainer.ExplainFailure(x)
+	}
+	return "", false
+}
+
+func (m namedMatcher) Got(x any) string {
+	return formatGot(x, m.inner)
+}
+
+// Attaches a message to a matcher, explaining why the expectation matters.
+// The message is included in the failure output of ExpectThat() and
+// AssertThat(), on a line after the value that was got, but doesn't change
+// which values match.
+//
+// The message is only reported when this is the top-level matcher passed to
+// an assertion; it has no effect when nested inside other matchers.
+//
+// Example:
+//
+//	ExpectThat(t, resp.Code, WithMessage(200, "request to %s failed", url))
+func WithMessage(m any, format string, args ...any) Matcher {
+	return messageMatcher{AsMatcher(m), fmt.Sprintf(format, args...)}
+}
+
+type messageMatcher struct {
+	inner   Matcher
+	message string
+}
+
+func (m messageMatcher) Matches(x any) bool {
+	return m.inner.Matches(x)
+}
+
+func (m messageMatcher) String() string {
+	return m.inner.String()
+}
+
+func (m messageMatcher) ExplainFailure(x any) (string, bool) {
+	if explainer, ok := m.inner.(MismatchExplainer); ok {
+		return explainer.ExplainFailure(x)
+	}
+	return "", false
+}
+
+func (m messageMatcher) Got(x any) string {
+	return formatGot(x, m.inner)
+}
+
+func (m messageMatcher) failureMessage() string {
+	return m.message
+}
+
+// Overrides DefaultOutputLimits for failures of this matcher, e.g. to see the
+// whole of a large value, or to shorten a verbose explanation. The limits
+// apply to the Got value and to the explanation separately.
+//
+// Like WithMessage(), this only has an effect on the top-level matcher passed
+// to an assertion.
+//
+// Example:
+//
+//	ExpectThat(t, hugeReport, WithOutputLimits(Eq(want), OutputLimits{MaxLines: 1000}))
+func WithOutputLimits(m any, limits OutputLimits) Matcher {
+	return limitsMatcher{AsMatcher(m), limits}
+}
+
+type limitsMatcher struct {
+	inner  Matcher
+	limits OutputLimits
+}
+
+func (m limitsMatcher) Matches(x any) bool {
+	return m.inner.Matches(x)
+}
+
+func (m limitsMatcher) String() string {
+	return m.inner.String()
+}
+
+func (m limitsMatcher) ExplainFailure(x any) (string, bool) {
+	if explainer, ok := m.inner.(MismatchExplainer); ok {
+		return explainer.ExplainFailure(x)
+	}
+	return "", false
+}
+
+func (m limitsMatcher) Got(x any) string {
+	return formatGot(x, m.inner)
+}
+
+func (m limitsMatcher) outputLimits() OutputLimits {
+	return m.limits
+}
+
+// Passes on the message of a WithMessage() matcher inside this one.
+func (m limitsMatcher) failureMessage() string {
+	if annotated, ok := m.inner.(messageAnnotator); ok {
+		return annotated.failureMessage()
+	}
+	return ""
+}
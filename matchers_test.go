@@ -0,0 +1,194 @@
+package gotest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatcherFunc(t *testing.T) {
+	isEven := MatcherFunc("is even", func(x any) bool {
+		i, ok := x.(int)
+		return ok && i%2 == 0
+	})
+	ExpectThat(t, 4, isEven)
+	ExpectThat(t, 3, Not(isEven))
+	ExpectThat(t, "4", Not(isEven))
+	ExpectThat(t, []int{2, 4}, ElementsAre(isEven, isEven))
+
+	r := testReporter{}
+	ExpectThat(&r, 3, isEven)
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is even",
+		"  Got: 3 (int)",
+	}, "\n"))
+}
+
+func TestNamed(t *testing.T) {
+	ExpectThat(t, "hello", Named("greeting", HasSubstr("ell")))
+	ExpectThat(t, "hello", Named("greeting", "hello"))
+	ExpectThat(t, "hello", Not(Named("greeting", "bye")))
+	ExpectEq(t, Named("greeting", "hello").String(), "greeting")
+
+	// The inner explanation is preserved
+	r := testReporter{}
+	ExpectThat(&r, []int{1, 2}, Named("short list", Len(3)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: short list",
+		"  Got: [1 2] ([]int)",
+		"  ...where length is 2",
+	}, "\n"))
+
+	// ...including when nested
+	r.Reset()
+	ExpectThat(&r, []int{1, 2}, ElementsAre(1, Named("big", Gt(5))))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has elements matching [is equal to 1 (int); big]",
+		"  Got: [1 2] ([]int)",
+		"  ...where element 1: doesn't match",
+	}, "\n"))
+}
+
+func TestWithMessage(t *testing.T) {
+	ExpectThat(t, 200, WithMessage(200, "request failed"))
+	ExpectThat(t, 404, Not(WithMessage(200, "request failed")))
+
+	r := testReporter{}
+	ExpectThat(&r, 404, WithMessage(Lt(400), "request to %s failed", "/index"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is less than 400 (int)",
+		"  Got: 404 (int)",
+		"  Message: request to /index failed",
+	}, "\n"))
+
+	r.Reset()
+	AssertThat(&r, "abc", WithMessage(Len(2), "wrong length"))
+	ExpectEq(t, r.fatals[0], strings.Join([]string{
+		"Assertion failed:",
+		"  Wanted: has length which is equal to 2 (int)",
+		"  Got: abc (string)",
+		"  Message: wrong length",
+		"  ...where length is 3",
+	}, "\n"))
+
+	// The output isn't a format string, so a '%' in it is shown as is.
+	r.Reset()
+	ExpectThat(&r, "50%", WithMessage(HasSubstr("100%"), "want %d%% sure", 100))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has substring '100%'",
+		"  Got: 50% (string)",
+		"  Message: want 100% sure",
+	}, "\n"))
+}
+
+func TestWithOutputLimits(t *testing.T) {
+	manyLines := strings.Repeat("line\n", 9) + "line"
+	r := testReporter{}
+	ExpectThat(&r, manyLines, WithOutputLimits(WithMessage("", "too long"), OutputLimits{MaxLines: 3}))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), ElementsAre(
+		"Expectation failed:",
+		"  Wanted: is equal to  (string)",
+		"  Got: line",
+		"line",
+		"line",
+		"... 7 more lines truncated",
+		"  Message: too long",
+		"  ...where doesn't match (-want +got):",
+		Any(),
+		Any(),
+		"... 2 more lines truncated",
+	))
+
+	r.Reset()
+	ExpectThat(&r, manyLines, WithOutputLimits(Empty(), OutputLimits{MaxBytes: 12}))
+	ExpectThat(t, r.nonFatals[0], StartsWith("Expectation failed:\n  Wanted: is empty\n  Got: line\nline\n... 8 more lines truncated\n"))
+
+	r.Reset()
+	ExpectThat(&r, strings.Repeat("é", 10), WithOutputLimits(Empty(), OutputLimits{MaxBytes: 5}))
+	ExpectThat(t, r.nonFatals[0], StartsWith("Expectation failed:\n  Wanted: is empty\n  Got: éé\n... 25 more bytes truncated\n"))
+
+	// A trailing newline doesn't start another line.
+	ExpectEq(t, truncate("a\nb\n", OutputLimits{MaxLines: 1}), "a\n... 1 more lines truncated")
+	ExpectEq(t, truncate("a\nb\nc", OutputLimits{MaxLines: 1}), "a\n... 2 more lines truncated")
+
+	r.Reset()
+	t.Setenv(NoTruncationEnv, "1")
+	ExpectThat(&r, manyLines, WithOutputLimits(Empty(), OutputLimits{MaxLines: 3}))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("truncated")))
+}
+
+func TestDefaultOutputLimits(t *testing.T) {
+	defer func(limits OutputLimits) { DefaultOutputLimits = limits }(DefaultOutputLimits)
+	DefaultOutputLimits = OutputLimits{MaxLines: 2}
+
+	r := testReporter{}
+	ExpectThat(&r, []int{1, 2, 3}, Len(2))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has length which is equal to 2 (int)",
+		"  Got: [1 2 3] ([]int)",
+		"  ...where length is 3",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, strings.Repeat("x\n", 5), Empty())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("  Got: x\nx\n... 4 more lines truncated"))
+}
+
+type myString string
+
+func TestIsA(t *testing.T) {
+	ExpectThat(t, 5, IsA[int]())
+	ExpectThat(t, int32(5), Not(IsA[int]()))
+	ExpectThat(t, "x", IsA[string]())
+	ExpectThat(t, myString("x"), Not(IsA[string]()))
+	ExpectThat(t, errors.New("x"), IsA[error]())
+	ExpectThat(t, &bytes.Buffer{}, IsA[io.Reader]())
+	ExpectThat(t, bytes.Buffer{}, Not(IsA[io.Reader]()))
+	ExpectThat(t, nil, Not(IsA[error]()))
+	ExpectThat(t, nil, Not(IsA[any]()))
+	ExpectThat(t, 5, IsA[any]())
+
+	r := testReporter{}
+	ExpectThat(&r, int32(5), IsA[int]())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a int",
+		"  Got: 5 (int32)",
+		"  ...where dynamic type is int32",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, nil, IsA[error]())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: implements error",
+		"  Got: <nil> (<nil>)",
+		"  ...where value is nil, with no dynamic type",
+	}, "\n"))
+}
+
+func TestTypeIs(t *testing.T) {
+	ExpectThat(t, 5, TypeIs(reflect.TypeOf(0)))
+	ExpectThat(t, int32(5), Not(TypeIs(reflect.TypeOf(0))))
+	ExpectThat(t, myString("x"), Not(TypeIs(reflect.TypeOf(""))))
+	ExpectThat(t, errors.New("x"), Not(TypeIs(reflect.TypeFor[error]())))
+	ExpectThat(t, nil, Not(TypeIs(reflect.TypeOf(0))))
+
+	r := testReporter{}
+	ExpectThat(&r, myString("x"), TypeIs(reflect.TypeOf("")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has type string",
+		"  Got: x (gotest.myString)",
+		"  ...where dynamic type is gotest.myString",
+	}, "\n"))
+}
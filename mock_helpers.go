@@ -0,0 +1,207 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.uber.org/mock/gomock"
+)
+
+// A gomock argument matcher that accepts any value of type T, and appends it
+// to `dst`. This allows arguments of mock calls to be checked afterwards with
+// ExpectThat(), rather than with gomock's own matching.
+//
+// gomock may match an argument more than once, e.g. when several expected
+// calls are candidates for the same method, so only use a capturing matcher
+// in a single expectation per method.
+//
+// Example:
+//
+//	var requests []*pb.Request
+//	mock.EXPECT().Send(gomock.Any(), CaptureArg(&requests)).Return(nil)
+//	client.Run(ctx)
+//	ExpectThat(t, requests, ElementsAre(ProtoEq(wantFirst), ProtoEq(wantSecond)))
+func CaptureArg[T any](dst *[]T) gomock.Matcher {
+	return argCaptor[T]{dst}
+}
+
+type argCaptor[T any] struct {
+	dst *[]T
+}
+
+func (m argCaptor[T]) Matches(x any) bool {
+	var val T
+	if x != nil {
+		var ok bool
+		if val, ok = x.(T); !ok {
+			return false
+		}
+	} else if !isNillable(reflect.TypeFor[T]()) {
+		return false
+	}
+	*m.dst = append(*m.dst, val)
+	return true
+}
+
+func (m argCaptor[T]) String() string {
+	return fmt.Sprintf("is any %s (captured)", reflect.TypeFor[T]())
+}
+
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// Tests the arguments of each invocation of `call` against `matchers`, one
+// for each parameter of the mocked method, and causes the test to fail if
+// they don't match. As with ExpectThat(), a matcher can also be a plain value,
+// which is compared with Eq().
+//
+// Unlike gomock's argument matchers, which only report that no expected call
+// matched, this reports which argument failed and why. The call's own
+// matchers still decide whether it's selected, so they're usually
+// gomock.Any().
+//
+// Failures are never fatal, even with FailFast(), since the mocked method may
+// be called on a goroutine other than the test's.
+//
+// For a variadic method, the last matcher is tested against the variadic
+// arguments as a []any, unless there's exactly one, which is tested on its
+// own, as gomock's matchers are.
+//
+// Examples:
+//
+//	call := mock.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+//	ExpectCallArgs(t, call, "alice", ProtoEq(&pb.Message{Body: "hi"}))
+//
+//	call := mockLogger.EXPECT().Logf(gomock.Any(), gomock.Any()).AnyTimes()
+//	ExpectCallArgs(t, call, HasSubstr("%d"), ElementsAre(Gt(0)))
+func ExpectCallArgs(t gomock.TestHelper, call *gomock.Call, matchers ...any) *gomock.Call {
+	t.Helper()
+	ms := make([]Matcher, len(matchers))
+	for i, m := range matchers {
+		ms[i] = AsMatcher(m)
+	}
+	return doWithArgs(call, len(ms), func(args []any) {
+		t.Helper()
+		checkCallArgs(t, "call", ms, args)
+	})
+}
+
+// Tests the number of times `call` is made against `times`, when the test
+// finishes, and the arguments of each invocation against `args`, one for each
+// parameter of the mocked method, as ExpectCallArgs() does. As with
+// ExpectThat(), `times` and each of `args` can be a matcher, or a plain value,
+// which is compared with Eq().
+//
+// Unlike gomock's own Times(), which reports a missing call without saying
+// how many were made, the failure shows the count that was wanted and the one
+// that was got. gomock doesn't reject calls beyond the wanted number, which
+// are reported in the same way.
+//
+// A matcher must be given for every parameter, even if it's Any(), since the
+// counting can't otherwise be attached to the call.
+//
+// Example:
+//
+//	ExpectCalled(t, mockStorage.EXPECT().Save(gomock.Any()), 2, Field("ID", 7)).Return(nil)
+func ExpectCalled(t interface {
+	gomock.TestHelper
+	Cleanup(func())
+}, call *gomock.Call, times any, args ...any) *gomock.Call {
+	t.Helper()
+	ms := make([]Matcher, len(args))
+	for i, m := range args {
+		ms[i] = AsMatcher(m)
+	}
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	doWithArgs(call, len(ms), func(args []any) {
+		t.Helper()
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		checkCallArgs(t, fmt.Sprintf("call %d", n), ms, args)
+	})
+	call.AnyTimes()
+	timesMatcher := AsMatcher(times)
+	m := Named("number of calls "+timesMatcher.String(), timesMatcher)
+	t.Cleanup(func() {
+		t.Helper()
+		mu.Lock()
+		n := calls
+		mu.Unlock()
+		if !m.Matches(n) {
+			// Like the arguments, the count isn't fatal even with FailFast().
+			c := configFor(t)
+			c.failFast = false
+			c.fail(t, false, getExplanation(fmt.Sprintf("Expectation for calls to %s", call), m, n))
+		}
+	})
+	return call
+}
+
+// Adds an action to `call` that passes its arguments to `f`, one for each of
+// the `numArgs` parameters of the mocked method. gomock requires the function
+// passed to Do() to have as many parameters as the method, so it's built to
+// order.
+//
+// The function's last parameter is variadic, since gomock passes the
+// arguments of a variadic method flattened: any number of them may follow the
+// fixed ones. As with gomock's own matching, unless there's exactly one, they
+// are passed to `f` together, as a []any.
+func doWithArgs(call *gomock.Call, numArgs int, f func(args []any)) *gomock.Call {
+	if numArgs == 0 {
+		return call.Do(func() { f(nil) })
+	}
+	in := make([]reflect.Type, numArgs)
+	for i := range in {
+		in[i] = reflect.TypeFor[any]()
+	}
+	in[numArgs-1] = reflect.TypeFor[[]any]()
+	action := reflect.MakeFunc(reflect.FuncOf(in, nil, true), func(vals []reflect.Value) []reflect.Value {
+		args := make([]any, numArgs-1, numArgs)
+		for i, v := range vals[:numArgs-1] {
+			args[i] = v.Interface()
+		}
+		rest := vals[numArgs-1].Interface().([]any)
+		for i, v := range rest {
+			// gomock passes a nil argument as the zero value of the
+			// parameter's type, which is []any for the variadic one.
+			if v, ok := v.([]any); ok && v == nil {
+				rest[i] = nil
+			}
+		}
+		if len(rest) == 1 {
+			args = append(args, rest[0])
+		} else {
+			args = append(args, rest)
+		}
+		f(args)
+		return nil
+	})
+	return call.Do(action.Interface())
+}
+
+// Tests the arguments of an invocation of a mocked method against `ms`.
+//
+// Mismatches are never fatal, even with FailFast(), since the mocked method
+// may be called on a goroutine other than the test's, where t.Fatalf() can't
+// stop the test.
+func checkCallArgs(t gomock.TestHelper, what string, ms []Matcher, args []any) {
+	t.Helper()
+	c := configFor(t)
+	c.failFast = false
+	for i, val := range args {
+		if m := c.configure(ms[i]); !m.Matches(val) {
+			c.fail(t, false, getExplanation(fmt.Sprintf("Argument %d of %s", i, what), m, val))
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gotest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// A minimal equivalent of a mockgen-generated mock.
+type mockSender struct {
+	ctrl *gomock.Controller
+}
+
+func (m *mockSender) Send(to string, body any) error {
+	ret := m.ctrl.Call(m, "Send", to, body)
+	err, _ := ret[0].(error)
+	return err
+}
+
+func (m *mockSender) expectSend(to, body any) *gomock.Call {
+	return m.ctrl.RecordCallWithMethodType(m, "Send", reflect.TypeOf((*mockSender)(nil).Send), to, body)
+}
+
+type mockLogger struct {
+	ctrl *gomock.Controller
+}
+
+func (m *mockLogger) Logf(format string, args ...any) {
+	varargs := []any{format}
+	varargs = append(varargs, args...)
+	m.ctrl.Call(m, "Logf", varargs...)
+}
+
+func (m *mockLogger) expectLogf(format any, args ...any) *gomock.Call {
+	varargs := append([]any{format}, args...)
+	return m.ctrl.RecordCallWithMethodType(m, "Logf", reflect.TypeOf((*mockLogger)(nil).Logf), varargs...)
+}
+
+func TestCaptureArg(t *testing.T) {
+	m := &mockSender{gomock.NewController(t)}
+	var bodies []string
+	m.expectSend(gomock.Any(), CaptureArg(&bodies)).Return(nil).Times(2)
+	m.Send("alice", "hello")
+	m.Send("bob", "goodbye")
+	ExpectThat(t, bodies, ElementsAre("hello", "goodbye"))
+
+	var errs []error
+	ExpectThat(t, nil, CaptureArg(&errs))
+	ExpectThat(t, 5, Not(CaptureArg(&errs)))
+	ExpectThat(t, errs, ElementsAre(Nil()))
+	ExpectThat(t, nil, Not(CaptureArg(&bodies)))
+
+	ExpectEq(t, CaptureArg(&bodies).String(), "is any string (captured)")
+}
+
+func TestExpectCallArgs(t *testing.T) {
+	m := &mockSender{gomock.NewController(t)}
+	call := m.expectSend(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	r := testReporter{}
+	ExpectCallArgs(&r, call, "alice", HasSubstr("hi"))
+	m.Send("alice", "hi there")
+	ExpectEq(t, r.HasFailures(), false)
+
+	m.Send("bob", 5)
+	ExpectThat(t, r.nonFatals, ElementsAre(
+		StartsWith(strings.Join([]string{
+			"Argument 0 of call failed:",
+			"  Wanted: is equal to alice (string)",
+			"  Got: bob (string)",
+		}, "\n")),
+		strings.Join([]string{
+			"Argument 1 of call failed:",
+			"  Wanted: has substring 'hi'",
+			"  Got: 5 (int)",
+			"  ...where value is of type int, not a string",
+		}, "\n"),
+	))
+}
+
+func TestExpectCallArgs_Variadic(t *testing.T) {
+	m := &mockLogger{gomock.NewController(t)}
+	call := m.expectLogf(HasSubstr(" "), gomock.Any()).AnyTimes()
+	r := testReporter{}
+	ExpectCallArgs(&r, call, Any(), Each(Gt(0)))
+	m.Logf("%d and %d", 1, 2)
+	m.Logf("no args")
+	m.Logf("%d %d", 3, -1)
+	ExpectThat(t, r.nonFatals, ElementsAre(strings.Join([]string{
+		"Argument 1 of call failed:",
+		"  Wanted: each element is greater than 0 (int)",
+		"  Got: [3 -1] ([]interface {})",
+		"  ...where element 1 is -1 (int)",
+	}, "\n")))
+
+	// A single variadic argument is tested on its own.
+	call = m.expectLogf(Not(HasSubstr(" ")), gomock.Any()).AnyTimes()
+	r.Reset()
+	ExpectCallArgs(&r, call, Any(), Nil())
+	m.Logf("%v", nil)
+	m.Logf("%d", 7)
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("  Got: 7 (int)")))
+}
+
+func TestExpectCalled(t *testing.T) {
+	r := &cleanupReporter{}
+	m := &mockSender{gomock.NewController(r)}
+	ExpectCalled(r, m.expectSend("alice", gomock.Any()), 2, Any(), HasSubstr("hi")).Return(nil)
+	ExpectCalled(r, m.expectSend("bob", gomock.Any()), 1, "bob", Any())
+	ExpectCalled(r, m.expectSend("carol", gomock.Any()), Le(1), Any(), Any())
+	m.Send("alice", "hi")
+	m.Send("alice", "hi there")
+	m.Send("bob", "hello")
+	r.finish()
+	ExpectEq(t, r.HasFailures(), false)
+
+	r = &cleanupReporter{}
+	m = &mockSender{gomock.NewController(r)}
+	ExpectCalled(r, m.expectSend("alice", gomock.Any()), 2, Any(), HasSubstr("hi")).Return(nil)
+	ExpectCalled(r, m.expectSend("bob", gomock.Any()), Le(1), Any(), Any())
+	m.Send("alice", "bye")
+	m.Send("bob", "hello")
+	m.Send("bob", "goodbye")
+	r.finish()
+	ExpectEq(t, r.fatals, nil)
+	ExpectThat(t, r.nonFatals, ElementsAre(
+		strings.Join([]string{
+			"Argument 1 of call 1 failed:",
+			"  Wanted: has substring 'hi'",
+			"  Got: bye (string)",
+		}, "\n"),
+		AllOf(
+			StartsWith("Expectation for calls to *gotest.mockSender.Send(is equal to alice (string), is anything) "),
+			HasSubstr(strings.Join([]string{
+				" failed:",
+				"  Wanted: number of calls is equal to 2 (int)",
+				"  Got: 1 (int)",
+			}, "\n")),
+		),
+		HasSubstr(strings.Join([]string{
+			" failed:",
+			"  Wanted: number of calls is less than or equal to 1 (int)",
+			"  Got: 2 (int)",
+		}, "\n")),
+	))
+}
@@ -0,0 +1,150 @@
+package gotest
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Matches functions of type func() that panic when called.
+//
+// The function is called each time the matcher is checked, so on failure it's
+// called a second time to explain the mismatch.
+//
+// Examples:
+//
+//	ExpectThat(t, func() { mustParse("???") }, Panics())
+//	ExpectThat(t, func() { mustParse("42") }, Not(Panics()))
+func Panics() Matcher {
+	return panicMatcher{}
+}
+
+// Matches functions of type func() that panic when called, with a recovered
+// value that fulfills `inner`.
+//
+// The function is called each time the matcher is checked, so on failure it's
+// called a second time to explain the mismatch.
+//
+// Examples:
+//
+//	ExpectThat(t, func() { panic("boom") }, PanicsWithValue("boom"))
+//	ExpectThat(t, func() { var m map[string]int; m["a"] = 1 }, PanicsWithValue(ErrorMessage(HasSubstr("nil map"))))
+//	tests := []struct {
+//		input string
+//		want  Matcher
+//	}{
+//		{"42", Not(Panics())},
+//		{"", PanicsWithValue(HasSubstr("empty input"))},
+//	}
+func PanicsWithValue(inner any) Matcher {
+	return panicMatcher{AsMatcher(inner)}
+}
+
+type panicMatcher struct {
+	// If nil, any panic matches.
+	inner Matcher
+}
+
+// Calls `f`, and returns the value it panicked with, if it did.
+func recoverFrom(f func()) (recovered any, panicked bool) {
+	defer func() {
+		if panicked {
+			recovered = recover()
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return nil, false
+}
+
+// Same as recoverFrom(), but also returns the stack trace of the panic.
+//
+// A function that exits its goroutine with runtime.Goexit(), as t.FailNow()
+// does, hasn't panicked, so it's allowed to carry on exiting.
+func recoverWithStack(f func()) (recovered any, stack []byte, panicked bool) {
+	defer func() {
+		if panicked {
+			if recovered = recover(); recovered != nil {
+				stack = debug.Stack()
+			} else {
+				panicked = false
+			}
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return nil, nil, false
+}
+
+// How a function run by goGuarded() ended, if not by returning.
+type guardedExit struct {
+	recovered any
+	panicked  bool
+	// Whether it called runtime.Goexit(), e.g. through t.FailNow().
+	goexit bool
+}
+
+// Runs `f` on a new goroutine, and returns a channel that receives how it
+// ended once it has. Unlike with a bare goroutine, a panic in `f` doesn't end
+// the test binary, and a call to t.FailNow() doesn't leave the caller waiting
+// forever: either is passed on to the test with reraise().
+func goGuarded(f func()) <-chan guardedExit {
+	// Buffered, so that a goroutine that's been abandoned can still finish.
+	done := make(chan guardedExit, 1)
+	go func() {
+		exit := guardedExit{goexit: true}
+		// Runs even if `f` calls runtime.Goexit(), which can't be recovered.
+		defer func() { done <- exit }()
+		exit.recovered, exit.panicked = recoverFrom(f)
+		exit.goexit = false
+	}()
+	return done
+}
+
+// Passes an abnormal end of a goroutine run by goGuarded() on to the test,
+// from the test's goroutine: a panic is raised again, and a call to
+// runtime.Goexit() causes the test to terminate. `what` names the check that
+// the goroutine was running.
+func (e guardedExit) reraise(t gomock.TestHelper, what string) {
+	t.Helper()
+	if e.panicked {
+		panic(e.recovered)
+	}
+	if e.goexit {
+		configFor(t).fail(t, true, fmt.Sprintf(
+			"%s stopped: runtime.Goexit() was called, e.g. by t.FailNow() on another goroutine", what))
+	}
+}
+
+func (m panicMatcher) Matches(x any) bool {
+	f, ok := x.(func())
+	if !ok {
+		return false
+	}
+	recovered, panicked := recoverFrom(f)
+	return panicked && (m.inner == nil || m.inner.Matches(recovered))
+}
+
+func (m panicMatcher) String() string {
+	if m.inner == nil {
+		return "is a function that panics"
+	}
+	return fmt.Sprintf("is a function that panics with a value which %s", m.inner.String())
+}
+
+func (m panicMatcher) ExplainFailure(x any) (string, bool) {
+	f, ok := x.(func())
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not func()", x), true
+	}
+	recovered, panicked := recoverFrom(f)
+	if !panicked {
+		return "function returned without panicking", true
+	}
+	explanation := fmt.Sprintf("function panicked with %s", formatGot(recovered, m.inner))
+	explanation = appendExplanation(explanation, m.inner, recovered)
+	return explanation, true
+}
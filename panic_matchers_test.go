@@ -0,0 +1,62 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPanics(t *testing.T) {
+	ExpectThat(t, func() { panic("boom") }, Panics())
+	ExpectThat(t, func() { var m map[string]int; m["a"] = 1 }, Panics())
+	ExpectThat(t, func() {}, Not(Panics()))
+	ExpectThat(t, func() int { panic("boom") }, Not(Panics()))
+
+	f := func() {}
+	r := &testReporter{}
+	ExpectThat(r, f, Panics())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a function that panics",
+		"  Got: " + formatGot(f, nil),
+		"  ...where function returned without panicking",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, "boom", Panics())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not func()"))
+}
+
+func TestPanicsWithValue(t *testing.T) {
+	ExpectThat(t, func() { panic("boom") }, PanicsWithValue("boom"))
+	ExpectThat(t, func() { panic("boom") }, PanicsWithValue(HasSubstr("oo")))
+	ExpectThat(t, func() { var m map[string]int; m["a"] = 1 }, PanicsWithValue(ErrorMessage(HasSubstr("nil map"))))
+	ExpectThat(t, func() { panic("boom") }, Not(PanicsWithValue("bang")))
+	ExpectThat(t, func() {}, Not(PanicsWithValue(Any())))
+
+	// Usable in compound matchers and table tests.
+	tests := []struct {
+		f    func()
+		want Matcher
+	}{
+		{func() {}, Not(Panics())},
+		{func() { panic(42) }, PanicsWithValue(Gt(40))},
+		{func() { panic(42) }, AllOf(Panics(), Not(PanicsWithValue("42")))},
+	}
+	for _, tc := range tests {
+		ExpectThat(t, tc.f, tc.want)
+	}
+
+	f := func() { panic("boom") }
+	r := &testReporter{}
+	ExpectThat(r, f, PanicsWithValue(StartsWith("bang")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a function that panics with a value which starts with 'bang'",
+		"  Got: " + formatGot(f, nil),
+		"  ...where function panicked with boom (string)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, func() {}, PanicsWithValue(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where function returned without panicking"))
+}
@@ -0,0 +1,61 @@
+package gotest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Number of times ExpectAllocs() runs the function to average its
+// allocations.
+const allocsRuns = 100
+
+// Tests that `f` allocates memory at most `maxAllocs` times per call, on
+// average, as measured by testing.AllocsPerRun(). `f` is called many times,
+// and shouldn't be run in parallel with other tests, which would also be
+// counted.
+//
+// Example:
+//
+//	ExpectAllocs(t, 0, func() { cache.Get("key") })
+func ExpectAllocs(t gomock.TestHelper, maxAllocs float64, f func()) bool {
+	t.Helper()
+	allocs := testing.AllocsPerRun(allocsRuns, f)
+	return ExpectThat(t, allocs, Named(fmt.Sprintf("allocates at most %v times per run", maxAllocs), Le(maxAllocs)))
+}
+
+// Tests that `f` returns within `d`. If it doesn't, the test fails once `d`
+// has passed, without waiting any longer for `f`, which is left running.
+//
+// `f` runs on another goroutine. If it panics, the panic is raised again on
+// the test's goroutine, and if it calls t.FailNow(), the test terminates.
+//
+// Example:
+//
+//	ExpectCompletesWithin(t, 100*time.Millisecond, func() { index.Rebuild() })
+func ExpectCompletesWithin(t gomock.TestHelper, d time.Duration, f func()) bool {
+	t.Helper()
+	start := time.Now()
+	var elapsed time.Duration
+	done := goGuarded(func() {
+		f()
+		elapsed = time.Since(start)
+	})
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case exit := <-done:
+		if exit.panicked || exit.goexit {
+			exit.reraise(t, "Expectation")
+			return false
+		}
+		// The timer can lose a race with `f` that finishes just after `d`.
+		return ExpectThat(t, elapsed, Named(fmt.Sprintf("completes within %s", d), Le(d)))
+	case <-timer.C:
+		configFor(t).fail(t, false, fmt.Sprintf(
+			"Expectation failed:\n  Wanted: completes within %s\n  Got: still running after %s", d, time.Since(start)))
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+package gotest
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var allocSink []int
+
+func TestExpectAllocs(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ExpectAllocs(&r, 0, func() {}), true)
+	ExpectEq(t, ExpectAllocs(&r, 1, func() { allocSink = make([]int, 10) }), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	ExpectEq(t, ExpectAllocs(&r, 1, func() {
+		allocSink = make([]int, 10)
+		allocSink = make([]int, 20)
+	}), false)
+	ExpectEq(t, r.nonFatals, []string{strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: allocates at most 1 times per run",
+		"  Got: 2 (float64)",
+	}, "\n")})
+}
+
+func TestExpectCompletesWithin(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ExpectCompletesWithin(&r, time.Second, func() {}), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	block := make(chan struct{})
+	defer close(block)
+	ExpectEq(t, ExpectCompletesWithin(&r, 10*time.Millisecond, func() { <-block }), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(ContainsRegex(
+		`^Expectation failed:\n  Wanted: completes within 10ms\n  Got: still running after [\d.]+m?s$`)))
+}
+
+func TestExpectCompletesWithin_PanicsAndGoexit(t *testing.T) {
+	r := testReporter{}
+	ExpectThat(t, func() {
+		ExpectCompletesWithin(&r, time.Second, func() { panic("boom") })
+	}, PanicsWithValue("boom"))
+
+	ExpectEq(t, ExpectCompletesWithin(&r, time.Second, func() { runtime.Goexit() }), false)
+	ExpectEq(t, r.nonFatals, nil)
+	ExpectEq(t, r.fatals, []string{
+		"Expectation stopped: runtime.Goexit() was called, e.g. by t.FailNow() on another goroutine",
+	})
+}
@@ -0,0 +1,383 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Applies the function `f` to the value, and matches the result against
+// `inner`. This makes it possible to match on computed properties of a value,
+// including inside other matchers like ElementsAre() or MapIs().
+//
+// `f` must be a function taking exactly one argument and returning exactly
+// one result. Values that can't be passed to `f` never match.
+//
+// Examples:
+//
+//	getEmail := func(u User) string { return u.Email }
+//	ExpectThat(t, user, Transform(getEmail, HasSubstr("@example.com")))
+//	ExpectThat(t, users, Contains(Transform(getEmail, "bob@example.com")))
+//	ExpectThat(t, "hello", Transform(strings.ToUpper, "HELLO"))
+func Transform(f any, inner any) Matcher {
+	fv := reflect.ValueOf(f)
+	if fv.Kind() != reflect.Func || fv.Type().NumIn() != 1 || fv.Type().NumOut() != 1 {
+		panic(fmt.Sprintf("Transform: expected a function with one argument and one result, got %T", f))
+	}
+	return transformMatcher{fv, AsMatcher(inner)}
+}
+
+type transformMatcher struct {
+	f     reflect.Value
+	inner Matcher
+}
+
+func (m transformMatcher) apply(x any) (any, bool) {
+	arg, ok := valueAs(x, m.f.Type().In(0))
+	if !ok {
+		return nil, false
+	}
+	return m.f.Call([]reflect.Value{arg})[0].Interface(), true
+}
+
+func (m transformMatcher) Matches(x any) bool {
+	if y, ok := m.apply(x); ok {
+		return m.inner.Matches(y)
+	}
+	return false
+}
+
+func (m transformMatcher) String() string {
+	return fmt.Sprintf("when transformed by %s, %s", m.f.Type(), m.inner.String())
+}
+
+func (m transformMatcher) ExplainFailure(x any) (string, bool) {
+	y, ok := m.apply(x)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, but the transformation takes %s",
+			x, m.f.Type().In(0)), true
+	}
+	explanation := fmt.Sprintf("transformed value is %s", formatGot(y, m.inner))
+	explanation = appendExplanation(explanation, m.inner, y)
+	return explanation, true
+}
+
+// Matches structs whose field `name` fulfills `inner`.
+//
+// `name` may be a dotted path to reach nested fields, e.g. "Address.City".
+// Pointers (and interfaces) along the path are dereferenced automatically;
+// values where the path can't be followed - because of a nil pointer, or a
+// missing field - never match. Only exported fields can be matched.
+//
+// Examples:
+//
+//	ExpectThat(t, user, Field("Name", "Alice"))
+//	ExpectThat(t, &user, Field("Address.City", StartsWith("San")))
+//	ExpectThat(t, users, Contains(Field("Email", HasSubstr("@example.com"))))
+func Field(name string, inner any) Matcher {
+	return fieldMatcher{name, AsMatcher(inner)}
+}
+
+type fieldMatcher struct {
+	path  string
+	inner Matcher
+}
+
+func (m fieldMatcher) Matches(x any) bool {
+	if val, problem := getField(x, m.path); problem == "" {
+		return m.inner.Matches(val)
+	}
+	return false
+}
+
+func (m fieldMatcher) String() string {
+	return fmt.Sprintf("has field %s which %s", m.path, m.inner.String())
+}
+
+func (m fieldMatcher) ExplainFailure(x any) (string, bool) {
+	return explainField(x, m.path, m.inner), true
+}
+
+// Matches structs whose fields fulfill all of `fields`, ignoring any fields
+// that aren't listed. Keys of `fields` are field names (or dotted paths), as
+// in Field(); values are exact values or matchers.
+//
+// This is equivalent to combining several Field() matchers, but with more
+// compact output.
+//
+// Examples:
+//
+//	ExpectThat(t, user, FieldsMatch(map[string]any{
+//		"Name":         "Alice",
+//		"Address.City": StartsWith("San"),
+//	}))
+//	ExpectThat(t, user, FieldsMatch(map[string]string{"Name": "Alice"}))
+func FieldsMatch[V any](fields map[string]V) Matcher {
+	paths := make([]string, 0, len(fields))
+	matchers := make(map[string]Matcher, len(fields))
+	for path, v := range fields {
+		paths = append(paths, path)
+		matchers[path] = AsMatcher(v)
+	}
+	slices.Sort(paths)
+	return fieldsMatcher{paths, matchers}
+}
+
+type fieldsMatcher struct {
+	// Sorted, for deterministic output
+	paths    []string
+	matchers map[string]Matcher
+}
+
+func (m fieldsMatcher) Matches(x any) bool {
+	for _, path := range m.paths {
+		val, problem := getField(x, path)
+		if problem != "" || !m.matchers[path].Matches(val) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m fieldsMatcher) String() string {
+	parts := make([]string, len(m.paths))
+	for i, path := range m.paths {
+		parts[i] = fmt.Sprintf("%s which %s", path, m.matchers[path].String())
+	}
+	return fmt.Sprintf("has fields [%s]", strings.Join(parts, "; "))
+}
+
+func (m fieldsMatcher) ExplainFailure(x any) (string, bool) {
+	problems := make([]string, 0)
+	for _, path := range m.paths {
+		val, problem := getField(x, path)
+		if problem == "" && m.matchers[path].Matches(val) {
+			continue
+		}
+		problems = append(problems, explainField(x, path, m.matchers[path]))
+	}
+	if len(problems) == 0 {
+		return "", false
+	}
+	return strings.Join(problems, "; "), true
+}
+
+// Looks up the (possibly dotted) field `path` within `x`. If the field can't
+// be reached, returns a description of the problem.
+func getField(x any, path string) (any, string) {
+	v := reflect.ValueOf(x)
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		var where string
+		if i == 0 {
+			where = "value"
+		} else {
+			where = "field " + strings.Join(names[:i], ".")
+		}
+
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Sprintf("%s is nil", where)
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Sprintf("%s is of type %s, not a struct", where, typeName(v))
+		}
+
+		f, ok := v.Type().FieldByName(name)
+		if !ok {
+			return nil, fmt.Sprintf("%s (%s) has no field %s", where, v.Type(), name)
+		}
+		if !f.IsExported() {
+			return nil, fmt.Sprintf("field %s is unexported",
+				strings.Join(names[:i+1], "."))
+		}
+		var err error
+		v, err = v.FieldByIndexErr(f.Index)
+		if err != nil {
+			return nil, fmt.Sprintf("field %s is unreachable: %s",
+				strings.Join(names[:i+1], "."), err)
+		}
+	}
+	return v.Interface(), ""
+}
+
+func explainField(x any, path string, inner Matcher) string {
+	val, problem := getField(x, path)
+	if problem != "" {
+		return problem
+	}
+	explanation := fmt.Sprintf("field %s is %s", path, formatGot(val, inner))
+	explanation = appendExplanation(explanation, inner, val)
+	return explanation
+}
+
+func typeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+// Calls the zero-argument method `methodName` on the value, and matches its
+// result against `inner`. The method must return exactly one result; values
+// without such a method never match.
+//
+// Methods with pointer receivers can be called on non-pointer values; they
+// operate on a copy of the value.
+//
+// Examples:
+//
+//	ExpectThat(t, resp, Property("StatusCode", 200))
+//	ExpectThat(t, err, Property("Error", HasSubstr("not found")))
+//	ExpectThat(t, buffers, Contains(Property("Len", Gt(0))))
+func Property(methodName string, inner any) Matcher {
+	return propertyMatcher{methodName, AsMatcher(inner)}
+}
+
+type propertyMatcher struct {
+	method string
+	inner  Matcher
+}
+
+// Calls the method on `x`. If that's not possible, returns a description of
+// the problem.
+func (m propertyMatcher) call(x any) (any, string) {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		return nil, "value is nil"
+	}
+	method := v.MethodByName(m.method)
+	if !method.IsValid() && v.Kind() != reflect.Pointer {
+		// Look for methods with pointer receivers.
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		method = ptr.MethodByName(m.method)
+	}
+	if !method.IsValid() {
+		return nil, fmt.Sprintf("type %T has no method %s", x, m.method)
+	}
+	if isNilValueReceiver(v, m.method) {
+		return nil, "value is nil"
+	}
+	if method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, fmt.Sprintf("method %s has type %s, expected no arguments and one result",
+			m.method, method.Type())
+	}
+	return method.Call(nil)[0].Interface(), ""
+}
+
+// Whether calling the method `name` on `v` would dereference a nil pointer:
+// the method has a value receiver, but `v` is a nil pointer. Methods with
+// pointer receivers can handle nil themselves, so they're still called.
+func isNilValueReceiver(v reflect.Value, name string) bool {
+	if v.Kind() != reflect.Pointer || !v.IsNil() || v.Type().Elem().Kind() == reflect.Interface {
+		return false
+	}
+	_, ok := v.Type().Elem().MethodByName(name)
+	return ok
+}
+
+func (m propertyMatcher) Matches(x any) bool {
+	if result, problem := m.call(x); problem == "" {
+		return m.inner.Matches(result)
+	}
+	return false
+}
+
+func (m propertyMatcher) String() string {
+	return fmt.Sprintf("has method %s() whose result %s", m.method, m.inner.String())
+}
+
+func (m propertyMatcher) ExplainFailure(x any) (string, bool) {
+	result, problem := m.call(x)
+	if problem != "" {
+		return problem, true
+	}
+	explanation := fmt.Sprintf("%s() returned %s", m.method, formatGot(result, m.inner))
+	explanation = appendExplanation(explanation, m.inner, result)
+	return explanation, true
+}
+
+// Matches values implementing fmt.Stringer whose String() result fulfills
+// `inner`. This makes enums and ID types readable in expectations, without
+// converting them by hand.
+//
+// Like Property(), String() methods with pointer receivers can be called on
+// non-pointer values.
+//
+// Examples:
+//
+//	ExpectThat(t, user.Status, StringerThat("ACTIVE"))
+//	ExpectThat(t, id, StringerThat(StartsWith("usr_")))
+//	ExpectThat(t, time.Second, StringerThat("1s"))
+func StringerThat(inner any) Matcher {
+	return stringerMatcher{AsMatcher(inner)}
+}
+
+type stringerMatcher struct {
+	inner Matcher
+}
+
+// Calls String() on `x`. If that's not possible, returns a description of the
+// problem.
+func (m stringerMatcher) call(x any) (string, string) {
+	v := reflect.ValueOf(x)
+	if s, ok := x.(fmt.Stringer); ok {
+		if isNilValueReceiver(v, "String") {
+			return "", "value is nil"
+		}
+		return s.String(), ""
+	}
+	if !v.IsValid() || v.Kind() == reflect.Pointer {
+		return "", fmt.Sprintf("type %T doesn't implement fmt.Stringer", x)
+	}
+	// Look for a String() method with a pointer receiver.
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	if s, ok := ptr.Interface().(fmt.Stringer); ok {
+		return s.String(), ""
+	}
+	return "", fmt.Sprintf("type %T doesn't implement fmt.Stringer", x)
+}
+
+func (m stringerMatcher) Matches(x any) bool {
+	if result, problem := m.call(x); problem == "" {
+		return m.inner.Matches(result)
+	}
+	return false
+}
+
+func (m stringerMatcher) String() string {
+	return fmt.Sprintf("is a fmt.Stringer whose String() %s", m.inner.String())
+}
+
+func (m stringerMatcher) ExplainFailure(x any) (string, bool) {
+	result, problem := m.call(x)
+	if problem != "" {
+		return problem, true
+	}
+	explanation := fmt.Sprintf("String() returned '%s'", result)
+	explanation = appendExplanation(explanation, m.inner, result)
+	return explanation, true
+}
+
+// Converts `x` into a reflect.Value of type `t`, if possible. Untyped nil is
+// converted to the zero value of nillable types.
+func valueAs(x any, t reflect.Type) (reflect.Value, bool) {
+	if x == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(t), true
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	v := reflect.ValueOf(x)
+	if !v.Type().AssignableTo(t) {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
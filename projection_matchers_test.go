@@ -0,0 +1,284 @@
+package gotest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type User struct {
+	Name  string
+	Email string
+}
+
+func TestTransform(t *testing.T) {
+	getEmail := func(u User) string { return u.Email }
+	alice := User{"Alice", "alice@example.com"}
+	bob := User{"Bob", "bob@example.org"}
+
+	ExpectThat(t, alice, Transform(getEmail, "alice@example.com"))
+	ExpectThat(t, alice, Transform(getEmail, HasSubstr("@example.com")))
+	ExpectThat(t, bob, Not(Transform(getEmail, HasSubstr("@example.com"))))
+	ExpectThat(t, "hello", Transform(strings.ToUpper, "HELLO"))
+	ExpectThat(t, []User{alice, bob}, Contains(Transform(getEmail, "bob@example.org")))
+
+	// Values of the wrong type don't match
+	ExpectThat(t, 12, Not(Transform(getEmail, Any())))
+	ExpectThat(t, nil, Not(Transform(getEmail, Any())))
+
+	// Interface arguments accept anything assignable, including nil
+	isNil := func(x any) bool { return x == nil }
+	ExpectThat(t, nil, Transform(isNil, true))
+	ExpectThat(t, 1, Transform(isNil, false))
+
+	// Invalid functions are rejected up-front
+	ExpectFatal(t, HasSubstr("expected a function"), func() {
+		Transform("not a func", Any())
+	})
+	ExpectFatal(t, HasSubstr("expected a function"), func() {
+		Transform(func(a, b int) int { return a + b }, Any())
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, bob, Transform(getEmail, HasSubstr("@example.com")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when transformed by func(gotest.User) string, has substring '@example.com'",
+		"  Got: {Bob bob@example.org} (gotest.User)",
+		"  ...where transformed value is bob@example.org (string)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, bob, Transform(getEmail, Len(3)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when transformed by func(gotest.User) string, has length which is equal to 3 (int)",
+		"  Got: {Bob bob@example.org} (gotest.User)",
+		"  ...where transformed value is bob@example.org (string), where length is 15",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 12, Transform(getEmail, Any()))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when transformed by func(gotest.User) string, is anything",
+		"  Got: 12 (int)",
+		"  ...where value is of type int, but the transformation takes gotest.User",
+	}, "\n"))
+}
+
+type Address struct {
+	City string
+	Zip  *string
+}
+
+type Customer struct {
+	User
+	Address  *Address
+	Previous any
+	secret   string
+}
+
+func TestField(t *testing.T) {
+	zip := "94110"
+	c := Customer{
+		User:    User{"Alice", "alice@example.com"},
+		Address: &Address{City: "San Francisco", Zip: &zip},
+		secret:  "shh",
+	}
+
+	ExpectThat(t, c.User, Field("Name", "Alice"))
+	ExpectThat(t, c, Field("Address.City", StartsWith("San")))
+	ExpectThat(t, &c, Field("Address.City", "San Francisco"))
+	ExpectThat(t, c, Field("Address.Zip", Not(Nil())))
+	ExpectThat(t, c, Field("Previous", Nil()))
+	ExpectThat(t, []User{c.User}, Contains(Field("Email", HasSubstr("@example.com"))))
+
+	// Embedded fields are promoted
+	ExpectThat(t, c, Field("Name", "Alice"))
+	ExpectThat(t, c, Field("User.Email", "alice@example.com"))
+
+	// Interfaces along the path are unwrapped
+	ExpectThat(t, Customer{Previous: &c}, Field("Previous.Address.City", "San Francisco"))
+
+	ExpectThat(t, c, Not(Field("Name", "Bob")))
+	ExpectThat(t, c, Not(Field("Nope", Any())))
+	ExpectThat(t, c, Not(Field("secret", Any())))
+	ExpectThat(t, Customer{}, Not(Field("Address.City", Any())))
+	ExpectThat(t, 12, Not(Field("Name", Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, c, Field("Address.City", Len(3)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has field Address.City which has length which is equal to 3 (int)",
+		"  Got: " + formatGot(c, nil),
+		"  ...where field Address.City is San Francisco (string), where length is 13",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, Customer{}, Field("Address.City", "x"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where field Address is nil"))
+
+	r.Reset()
+	ExpectThat(&r, c, Field("Address.Street", "x"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where field Address (gotest.Address) has no field Street"))
+
+	r.Reset()
+	ExpectThat(&r, c, Field("secret", "x"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where field secret is unexported"))
+
+	r.Reset()
+	ExpectThat(&r, c, Field("Name.First", "x"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where field Name is of type string, not a struct"))
+
+	r.Reset()
+	ExpectThat(&r, 12, Field("Name", "x"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a struct"))
+}
+
+func TestFieldsMatch(t *testing.T) {
+	c := Customer{
+		User:    User{"Alice", "alice@example.com"},
+		Address: &Address{City: "San Francisco"},
+	}
+
+	ExpectThat(t, c, FieldsMatch(map[string]any{}))
+	ExpectThat(t, c, FieldsMatch(map[string]any{
+		"Name":         "Alice",
+		"Address.City": StartsWith("San"),
+	}))
+	ExpectThat(t, c.User, FieldsMatch(map[string]string{
+		"Name":  "Alice",
+		"Email": "alice@example.com",
+	}))
+	ExpectThat(t, c, Not(FieldsMatch(map[string]any{
+		"Name":         "Alice",
+		"Address.City": "Oakland",
+	})))
+	ExpectThat(t, c, Not(FieldsMatch(map[string]any{"Nope": Any()})))
+	ExpectThat(t, 12, Not(FieldsMatch(map[string]any{"Name": Any()})))
+
+	r := testReporter{}
+	ExpectThat(&r, c, FieldsMatch(map[string]any{
+		"Name":           "Alice",
+		"Email":          HasSubstr("@example.org"),
+		"Address.City":   Len(3),
+		"Address.Street": "Main",
+	}))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has fields [" +
+			"Address.City which has length which is equal to 3 (int); " +
+			"Address.Street which is equal to Main (string); " +
+			"Email which has substring '@example.org'; " +
+			"Name which is equal to Alice (string)]",
+		"  Got: " + formatGot(c, nil),
+		"  ...where field Address.City is San Francisco (string), where length is 13; " +
+			"field Address (gotest.Address) has no field Street; " +
+			"field Email is alice@example.com (string)",
+	}, "\n"))
+}
+
+type counter struct {
+	n int
+}
+
+func (c counter) Count() int         { return c.n }
+func (c *counter) Doubled() int      { return c.n * 2 }
+func (c counter) Add(k int) int      { return c.n + k }
+func (c counter) Both() (int, error) { return c.n, nil }
+
+func TestProperty(t *testing.T) {
+	c := counter{3}
+	ExpectThat(t, c, Property("Count", 3))
+	ExpectThat(t, &c, Property("Count", Gt(2)))
+	ExpectThat(t, c, Property("Doubled", 6))
+	ExpectThat(t, &c, Property("Doubled", 6))
+	ExpectThat(t, errors.New("not found"), Property("Error", HasSubstr("found")))
+	ExpectThat(t, []counter{{1}, {5}}, Contains(Property("Count", Gt(4))))
+
+	ExpectThat(t, c, Not(Property("Count", 4)))
+	ExpectThat(t, c, Not(Property("Missing", Any())))
+	ExpectThat(t, c, Not(Property("Add", Any())))
+	ExpectThat(t, c, Not(Property("Both", Any())))
+	ExpectThat(t, nil, Not(Property("Count", Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, c, Property("Count", Gt(5)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has method Count() whose result is greater than 5 (int)",
+		"  Got: {3} (gotest.counter)",
+		"  ...where Count() returned 3 (int)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, c, Property("Missing", Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type gotest.counter has no method Missing"))
+
+	r.Reset()
+	ExpectThat(&r, c, Property("Add", Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(
+		"...where method Add has type func(int) int, expected no arguments and one result"))
+
+	// Methods with value receivers can't be called on nil pointers.
+	var nilCounter *counter
+	ExpectThat(t, nilCounter, Not(Property("Count", Any())))
+	r.Reset()
+	ExpectThat(&r, nilCounter, Property("Count", 0))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is nil"))
+}
+
+type status int
+
+func (s status) String() string {
+	return [...]string{"UNKNOWN", "ACTIVE", "DISABLED"}[s]
+}
+
+type userID struct {
+	n int
+}
+
+func (id *userID) String() string {
+	return fmt.Sprintf("usr_%d", id.n)
+}
+
+func TestStringerThat(t *testing.T) {
+	ExpectThat(t, status(1), StringerThat("ACTIVE"))
+	ExpectThat(t, status(2), StringerThat(HasSubstr("ABLE")))
+	ExpectThat(t, &userID{7}, StringerThat("usr_7"))
+	ExpectThat(t, userID{7}, StringerThat(StartsWith("usr_")))
+	ExpectThat(t, time.Second, StringerThat("1s"))
+	ExpectThat(t, []status{0, 1}, Contains(StringerThat("ACTIVE")))
+
+	ExpectThat(t, status(1), Not(StringerThat("DISABLED")))
+	ExpectThat(t, "ACTIVE", Not(StringerThat("ACTIVE")))
+	ExpectThat(t, nil, Not(StringerThat(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, status(2), StringerThat(HasSubstr("ACT")))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a fmt.Stringer whose String() has substring 'ACT'",
+		"  Got: DISABLED (gotest.status)",
+		"  ...where String() returned 'DISABLED'",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, userID{7}, StringerThat(Len(3)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where String() returned 'usr_7', where length is 5"))
+
+	r.Reset()
+	ExpectThat(&r, 12, StringerThat(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type int doesn't implement fmt.Stringer"))
+
+	// String() methods with value receivers can't be called on nil pointers.
+	var nilStatus *status
+	ExpectThat(t, nilStatus, Not(StringerThat(Any())))
+	r.Reset()
+	ExpectThat(&r, nilStatus, StringerThat("UNKNOWN"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is nil"))
+}
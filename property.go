@@ -0,0 +1,348 @@
+package gotest
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Produces random values of type T for ForAll(), and simpler values to shrink
+// a failing one to.
+type Generator[T any] interface {
+	Generate(r *rand.Rand) T
+	// Returns values that are simpler than `v`, simplest first, or none if
+	// `v` is as simple as it gets.
+	Shrink(v T) []T
+}
+
+// Returns a Generator made of the given functions. `shrink` may be nil, in
+// which case values aren't shrunk.
+//
+// Example:
+//
+//	evens := GeneratorFunc(func(r *rand.Rand) int { return 2 * r.IntN(100) }, nil)
+func GeneratorFunc[T any](generate func(r *rand.Rand) T, shrink func(v T) []T) Generator[T] {
+	return funcGenerator[T]{generate, shrink}
+}
+
+type funcGenerator[T any] struct {
+	generate func(r *rand.Rand) T
+	shrink   func(v T) []T
+}
+
+func (g funcGenerator[T]) Generate(r *rand.Rand) T {
+	return g.generate(r)
+}
+
+func (g funcGenerator[T]) Shrink(v T) []T {
+	if g.shrink == nil {
+		return nil
+	}
+	return g.shrink(v)
+}
+
+// Generates ints from `lo` to `hi`, inclusive. Values shrink towards zero, or
+// towards whichever bound is closer to it.
+func Ints(lo, hi int) Generator[int] {
+	if lo > hi {
+		panic(fmt.Sprintf("Ints: lo (%d) is greater than hi (%d)", lo, hi))
+	}
+	target := min(max(0, lo), hi)
+	// Computed without overflowing, even for the full range of ints.
+	span := uint64(hi) - uint64(lo)
+	return GeneratorFunc(func(r *rand.Rand) int {
+		if span == math.MaxUint64 {
+			return int(r.Uint64())
+		}
+		return lo + int(r.Uint64N(span+1))
+	}, func(v int) []int {
+		var candidates []int
+		for _, c := range []int{target, target + (v-target)/2, v - sign(v-target)} {
+			if c != v && !slices.Contains(candidates, c) {
+				candidates = append(candidates, c)
+			}
+		}
+		return candidates
+	})
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+// Generates float64s from `lo` up to `hi`. Values shrink towards zero, or
+// towards whichever bound is closer to it, and towards whole numbers.
+func Floats(lo, hi float64) Generator[float64] {
+	if lo > hi {
+		panic(fmt.Sprintf("Floats: lo (%v) is greater than hi (%v)", lo, hi))
+	}
+	target := math.Min(math.Max(0, lo), hi)
+	return GeneratorFunc(func(r *rand.Rand) float64 {
+		return lo + r.Float64()*(hi-lo)
+	}, func(v float64) []float64 {
+		var candidates []float64
+		for _, c := range []float64{target, target + (v-target)/2, math.Trunc(v)} {
+			if c != v && c >= lo && c <= hi && !slices.Contains(candidates, c) {
+				candidates = append(candidates, c)
+			}
+		}
+		return candidates
+	})
+}
+
+// Generates true and false. true shrinks to false.
+func Bools() Generator[bool] {
+	return GeneratorFunc(func(r *rand.Rand) bool {
+		return r.IntN(2) == 1
+	}, func(v bool) []bool {
+		if v {
+			return []bool{false}
+		}
+		return nil
+	})
+}
+
+// Generates one of `values`. Values shrink towards the first one.
+//
+// Example:
+//
+//	OneOf("GET", "POST", "DELETE")
+func OneOf[T any](values ...T) Generator[T] {
+	if len(values) == 0 {
+		panic("OneOf: no values")
+	}
+	return GeneratorFunc(func(r *rand.Rand) T {
+		return values[r.IntN(len(values))]
+	}, func(v T) []T {
+		for i, candidate := range values {
+			if reflect.DeepEqual(candidate, v) {
+				return slices.Clone(values[:i])
+			}
+		}
+		return nil
+	})
+}
+
+// Generates slices of up to `maxLen` elements made by `elem`. Slices shrink by
+// removing elements, and by shrinking the elements themselves.
+func SliceOf[T any](elem Generator[T], maxLen int) Generator[[]T] {
+	return GeneratorFunc(func(r *rand.Rand) []T {
+		s := make([]T, r.IntN(maxLen+1))
+		for i := range s {
+			s[i] = elem.Generate(r)
+		}
+		return s
+	}, func(v []T) [][]T {
+		if len(v) == 0 {
+			return nil
+		}
+		candidates := [][]T{{}}
+		// For shorter slices, these would repeat the candidates above.
+		if len(v) > 2 {
+			candidates = append(candidates, v[:len(v)/2], v[len(v)/2:])
+		}
+		if len(v) > 1 {
+			for i := range v {
+				candidates = append(candidates, slices.Delete(slices.Clone(v), i, i+1))
+			}
+		}
+		for i := range v {
+			for _, e := range elem.Shrink(v[i]) {
+				candidate := slices.Clone(v)
+				candidate[i] = e
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	})
+}
+
+// Generates strings of up to `maxLen` runes from `alphabet`, or from the
+// printable ASCII characters if it's empty. Strings shrink by removing runes,
+// and by replacing them with ones earlier in the alphabet.
+func Strings(alphabet string, maxLen int) Generator[string] {
+	if alphabet == "" {
+		for c := ' '; c <= '~'; c++ {
+			alphabet += string(c)
+		}
+	}
+	runes := SliceOf(OneOf([]rune(alphabet)...), maxLen)
+	return GeneratorFunc(func(r *rand.Rand) string {
+		return string(runes.Generate(r))
+	}, func(v string) []string {
+		var candidates []string
+		for _, c := range runes.Shrink([]rune(v)) {
+			candidates = append(candidates, string(c))
+		}
+		return candidates
+	})
+}
+
+// Generates structs of type T, whose fields are set by `fields`: a map from
+// the names of exported fields to Generators of their types. Other fields are
+// left as zero values. Structs shrink by shrinking one field at a time.
+//
+// Example:
+//
+//	users := StructOf[User](map[string]any{
+//		"Name": Strings("abc", 5),
+//		"Age":  Ints(0, 120),
+//	})
+func StructOf[T any](fields map[string]any) Generator[T] {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("StructOf: %s isn't a struct type", t))
+	}
+	names := make([]string, 0, len(fields))
+	for name, gen := range fields {
+		f, ok := t.FieldByName(name)
+		if !ok || !f.IsExported() {
+			panic(fmt.Sprintf("StructOf: %s has no exported field %s", t, name))
+		}
+		genType := reflect.TypeOf(gen)
+		generate, ok := genType.MethodByName("Generate")
+		if !ok || generate.Type.NumOut() != 1 || generate.Type.Out(0) != f.Type {
+			panic(fmt.Sprintf("StructOf: %T isn't a Generator[%s] for field %s", gen, f.Type, name))
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return GeneratorFunc(func(r *rand.Rand) T {
+		var v T
+		s := reflect.ValueOf(&v).Elem()
+		for _, name := range names {
+			out := reflect.ValueOf(fields[name]).MethodByName("Generate").Call([]reflect.Value{reflect.ValueOf(r)})
+			s.FieldByName(name).Set(out[0])
+		}
+		return v
+	}, func(v T) []T {
+		var candidates []T
+		for _, name := range names {
+			field := reflect.ValueOf(v).FieldByName(name)
+			shrunk := reflect.ValueOf(fields[name]).MethodByName("Shrink").Call([]reflect.Value{field})[0]
+			for i := range shrunk.Len() {
+				candidate := v
+				reflect.ValueOf(&candidate).Elem().FieldByName(name).Set(shrunk.Index(i))
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	})
+}
+
+// The outcome of checking a value in a property for ForAll(). See That().
+type Check struct {
+	val     any
+	matcher Matcher
+	ok      bool
+}
+
+// Checks whether `val` fulfills `matcher` (or is equal to it, for a value),
+// for a property passed to ForAll(). If not, ForAll() reports the matcher's
+// explanation.
+func That(val any, matcher any) Check {
+	m := AsMatcher(matcher)
+	return Check{val, m, m.Matches(val)}
+}
+
+// Configures ForAll().
+type PropertyOption func(*propertyConfig)
+
+type propertyConfig struct {
+	inputs int
+	seed   uint64
+	seeded bool
+}
+
+// Sets the number of random inputs that ForAll() tries. The default is 100.
+func Inputs(n int) PropertyOption {
+	return func(c *propertyConfig) {
+		c.inputs = n
+	}
+}
+
+// Sets the seed of the random inputs, e.g. to reproduce a failure, which
+// reports the seed it used.
+func WithSeed(seed uint64) PropertyOption {
+	return func(c *propertyConfig) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// Maximum number of times a failing input is shrunk.
+const maxShrinks = 1000
+
+// Tests that `property` holds for random inputs made by `gen`. The property
+// returns either a bool, or a Check from That(), whose matcher's explanation
+// is reported on failure.
+//
+// If the property fails for an input, the input is shrunk to the simplest one
+// the generator can find that still fails, and the test fails with that
+// counterexample, and the seed that reproduces it with WithSeed().
+//
+// Examples:
+//
+//	ForAll(t, SliceOf(Ints(-100, 100), 20), func(s []int) Check {
+//		return That(Reverse(Reverse(s)), s)
+//	})
+//	ForAll(t, Strings("", 10), func(s string) bool {
+//		return len(strings.ToUpper(s)) == len(s)
+//	}, Inputs(1000))
+func ForAll[T any, R bool | Check](t gomock.TestHelper, gen Generator[T], property func(T) R, opts ...PropertyOption) bool {
+	t.Helper()
+	config := propertyConfig{inputs: 100}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if !config.seeded {
+		config.seed = rand.Uint64()
+	}
+	r := rand.New(rand.NewPCG(config.seed, config.seed))
+
+	check := func(v T) Check {
+		result := any(property(v))
+		if c, ok := result.(Check); ok {
+			return c
+		}
+		return Check{ok: result.(bool)}
+	}
+	for i := 1; i <= config.inputs; i++ {
+		v := gen.Generate(r)
+		failure := check(v)
+		if failure.ok {
+			continue
+		}
+		shrinks := 0
+	shrinking:
+		for shrinks < maxShrinks {
+			for _, candidate := range gen.Shrink(v) {
+				if c := check(candidate); !c.ok {
+					v, failure = candidate, c
+					shrinks++
+					continue shrinking
+				}
+			}
+			break
+		}
+		context := fmt.Sprintf("Property for counterexample %#v (seed %d, input %d of %d, shrunk %d times)",
+			v, config.seed, i, config.inputs, shrinks)
+		if failure.matcher == nil {
+			configFor(t).fail(t, false, context+" failed: returned false")
+		} else {
+			configFor(t).fail(t, false, getExplanation(context, failure.matcher, failure.val))
+		}
+		return false
+	}
+	return true
+}
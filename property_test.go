@@ -0,0 +1,105 @@
+package gotest
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		ExpectThat(t, Ints(-5, 5).Generate(r), Within(-5, 5, IncludeHigh()))
+		ExpectThat(t, Floats(1, 2).Generate(r), Within(1.0, 2.0))
+		ExpectThat(t, OneOf("a", "b").Generate(r), AnyOf("a", "b"))
+		ExpectThat(t, SliceOf(Ints(0, 1), 3).Generate(r), AllOf(Len(Le(3)), Each(AnyOf(0, 1))))
+		ExpectThat(t, Strings("xy", 4).Generate(r), ContainsRegex(`^[xy]{0,4}$`))
+		ExpectThat(t, Strings("", 4).Generate(r), ContainsRegex(`^[ -~]{0,4}$`))
+	}
+	ExpectThat(t, Ints(math.MinInt, math.MaxInt).Generate(r), IsA[int]())
+	ExpectEq(t, Ints(7, 7).Generate(r), 7)
+
+	ExpectEq(t, Ints(-100, 100).Shrink(10), []int{0, 5, 9})
+	ExpectEq(t, Ints(-100, 100).Shrink(-1), []int{0})
+	ExpectEq(t, Ints(3, 100).Shrink(10), []int{3, 6, 9})
+	ExpectEq(t, Ints(-100, 100).Shrink(0), nil)
+	ExpectEq(t, Floats(-10, 10).Shrink(2.5), []float64{0, 1.25, 2})
+	ExpectEq(t, Bools().Shrink(true), []bool{false})
+	ExpectEq(t, Bools().Shrink(false), nil)
+	ExpectEq(t, OneOf("a", "b", "c").Shrink("c"), []string{"a", "b"})
+	ExpectEq(t, SliceOf(Bools(), 5).Shrink([]bool{true, false}), [][]bool{
+		{}, {false}, {true}, {false, false},
+	})
+	ExpectThat(t, SliceOf(Ints(0, 9), 5).Shrink([]int{1, 2, 3}),
+		Contains([]int{}, []int{1}, []int{2, 3}, []int{1, 3}, []int{1, 0, 3}, []int{1, 2, 2}))
+	ExpectEq(t, Strings("ab", 5).Shrink("b"), []string{"", "a"})
+}
+
+type shape struct {
+	Name  string
+	Sides int
+	color string
+}
+
+func TestStructOf(t *testing.T) {
+	gen := StructOf[shape](map[string]any{
+		"Name":  OneOf("a", "b"),
+		"Sides": Ints(3, 8),
+	})
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		ExpectThat(t, gen.Generate(r), AllOf(
+			Field("Name", AnyOf("a", "b")),
+			Field("Sides", Within(3, 8, IncludeHigh())),
+		))
+	}
+	ExpectEq(t, gen.Shrink(shape{Name: "b", Sides: 5}), []shape{
+		{Name: "a", Sides: 5}, {Name: "b", Sides: 3}, {Name: "b", Sides: 4},
+	})
+
+	ExpectThat(t, func() { StructOf[shape](map[string]any{"Size": Ints(0, 1)}) },
+		PanicsWithValue("StructOf: gotest.shape has no exported field Size"))
+	ExpectThat(t, func() { StructOf[shape](map[string]any{"color": Strings("", 1)}) }, Panics())
+	ExpectThat(t, func() { StructOf[shape](map[string]any{"Sides": Strings("", 1)}) },
+		PanicsWithValue(HasSubstr("isn't a Generator[int] for field Sides")))
+	ExpectThat(t, func() { StructOf[int](nil) }, PanicsWithValue("StructOf: int isn't a struct type"))
+}
+
+func TestForAll(t *testing.T) {
+	r := testReporter{}
+	ExpectEq(t, ForAll(&r, SliceOf(Ints(-100, 100), 10), func(s []int) Check {
+		reversed := slices.Clone(s)
+		slices.Reverse(reversed)
+		slices.Reverse(reversed)
+		return That(reversed, s)
+	}), true)
+	ExpectEq(t, ForAll(&r, Strings("", 10), func(s string) bool {
+		return len(strings.ToUpper(s)) == len(s)
+	}, Inputs(500)), true)
+	ExpectEq(t, r.HasFailures(), false)
+
+	// Shrinks to the smallest failing input.
+	ExpectEq(t, ForAll(&r, Ints(-1000, 1000), func(n int) bool {
+		return n < 17
+	}, WithSeed(5)), false)
+	ExpectThat(t, r.nonFatals, ElementsAre(ContainsRegex(
+		`^Property for counterexample 17 \(seed 5, input \d+ of 100, shrunk \d+ times\) failed: returned false$`)))
+
+	r.Reset()
+	ForAll(&r, SliceOf(Ints(0, 100), 10), func(s []int) Check {
+		return That(s, Each(Lt(50)))
+	}, WithSeed(1), Inputs(1000))
+	ExpectThat(t, r.nonFatals, ElementsAre(AllOf(
+		StartsWith("Property for counterexample []int{50} (seed 1, "),
+		HasSubstr(" failed:\n  Wanted: each element is less than 50 (int)\n  Got: [50] ([]int)\n"),
+	)))
+}
+
+func TestForAll_Seed(t *testing.T) {
+	var first, second []int
+	ForAll(t, Ints(0, 1000), func(n int) bool { first = append(first, n); return true }, WithSeed(42), Inputs(10))
+	ForAll(t, Ints(0, 1000), func(n int) bool { second = append(second, n); return true }, WithSeed(42), Inputs(10))
+	ExpectEq(t, first, second)
+}
@@ -0,0 +1,415 @@
+package gotest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// Matches protos that are equal to `msg`, as compared field-by-field by
+// protocmp. Unlike Eq(), which compares protos atomically, this can be
+// configured with ProtoOpts to disregard some differences, and its failure
+// output points at the fields that differ.
+//
+// Examples:
+//
+//	ExpectThat(t, resp, ProtoEq(&pb.Response{Status: "ok"}))
+//	ExpectThat(t, resp, ProtoEq(want, IgnoreFields("id", "metadata.created_at")))
+//	ExpectThat(t, resp, ProtoEq(want, IgnoreUnknownFields(), IgnoreRepeatedOrder()))
+func ProtoEq(msg proto.Message, opts ...ProtoOpt) Matcher {
+	m := protoEqMatcher{want: msg}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	for _, path := range m.ignoredFields {
+		if err := checkFieldPath(msg.ProtoReflect().Descriptor(), path); err != nil {
+			panic(fmt.Sprintf("ProtoEq: can't ignore field %q: %s", path, err))
+		}
+	}
+	return m
+}
+
+// Configures ProtoEq().
+type ProtoOpt func(*protoEqMatcher)
+
+// Ignores the fields at the given paths. A path is a sequence of field names,
+// separated by dots, leading from the top-level message to the field.
+// Repeated and map fields are passed through transparently, so that
+// "items.id" ignores the id field of every element of items.
+//
+// ProtoEq() panics if a path doesn't name a field of the expected message.
+func IgnoreFields(paths ...string) ProtoOpt {
+	return func(m *protoEqMatcher) {
+		m.ignoredFields = append(m.ignoredFields, paths...)
+		m.options = append(m.options, ignoreFieldPaths(paths))
+	}
+}
+
+// Ignores unknown fields, which are fields that were present when parsing a
+// message but aren't in its schema.
+func IgnoreUnknownFields() ProtoOpt {
+	return func(m *protoEqMatcher) {
+		m.notes = append(m.notes, "unknown fields")
+		m.options = append(m.options, protocmp.IgnoreUnknown())
+	}
+}
+
+// Ignores the order of the elements of repeated fields.
+//
+// Elements are put into a canonical order before comparing, without regard to
+// any fields ignored by IgnoreFields(), so elements that differ only in
+// ignored fields may still be reported as out of place.
+func IgnoreRepeatedOrder() ProtoOpt {
+	return func(m *protoEqMatcher) {
+		m.notes = append(m.notes, "repeated field order")
+		m.options = append(m.options, sortRepeatedFields())
+	}
+}
+
+// Treats google.protobuf.Timestamp messages as equal if they're within
+// `margin` of each other, for fields derived from a clock.
+//
+// Example:
+//
+//	ExpectThat(t, resp, ProtoEq(want, ApproxTimestamps(time.Second)))
+func ApproxTimestamps(margin time.Duration) ProtoOpt {
+	return func(m *protoEqMatcher) {
+		m.tolerances = append(m.tolerances, fmt.Sprintf("timestamps within %s", margin))
+		m.options = append(m.options, approxTimes("google.protobuf.Timestamp", margin))
+	}
+}
+
+// Treats google.protobuf.Duration messages as equal if they're within
+// `margin` of each other, for fields holding measured times.
+//
+// Example:
+//
+//	ExpectThat(t, resp, ProtoEq(want, ApproxDurations(10*time.Millisecond)))
+func ApproxDurations(margin time.Duration) ProtoOpt {
+	return func(m *protoEqMatcher) {
+		m.tolerances = append(m.tolerances, fmt.Sprintf("durations within %s", margin))
+		m.options = append(m.options, approxTimes("google.protobuf.Duration", margin))
+	}
+}
+
+// Treats float and double fields as equal if they're within `margin` of each
+// other, or within `fraction` of the larger magnitude of the two. Either may
+// be zero to only use the other. This applies to every floating-point field,
+// including those in nested messages and well-known wrapper types.
+//
+// Examples:
+//
+//	ExpectThat(t, scores, ProtoEq(want, ApproxFloats(0, 1e-6)))
+//	ExpectThat(t, prediction, ProtoEq(want, ApproxFloats(0.01, 0)))
+func ApproxFloats(fraction, margin float64) ProtoOpt {
+	var within []string
+	if margin != 0 || fraction == 0 {
+		within = append(within, fmt.Sprint(margin))
+	}
+	if fraction != 0 {
+		within = append(within, fmt.Sprintf("a fraction %v", fraction))
+	}
+	return func(m *protoEqMatcher) {
+		m.tolerances = append(m.tolerances, "floats within "+strings.Join(within, " or "))
+		m.options = append(m.options, cmpopts.EquateApprox(fraction, margin))
+	}
+}
+
+type protoEqMatcher struct {
+	want          proto.Message
+	options       []cmp.Option
+	ignoredFields []string
+
+	// Descriptions of the other differences being ignored.
+	notes []string
+
+	// Descriptions of the differences being tolerated.
+	tolerances []string
+}
+
+func (m protoEqMatcher) cmpOptions() []cmp.Option {
+	return append([]cmp.Option{protocmp.Transform()}, m.options...)
+}
+
+func (m protoEqMatcher) Matches(x any) bool {
+	got, ok := x.(proto.Message)
+	if !ok {
+		return false
+	}
+	return cmp.Equal(m.want, got, m.cmpOptions()...)
+}
+
+func (m protoEqMatcher) String() string {
+	desc := fmt.Sprintf("is a proto equal to %v (%T)", m.want, m.want)
+	var ignoring []string
+	if len(m.ignoredFields) > 0 {
+		ignoring = append(ignoring, fmt.Sprintf("fields [%s]", strings.Join(m.ignoredFields, ", ")))
+	}
+	ignoring = append(ignoring, m.notes...)
+	if len(ignoring) > 0 {
+		desc += fmt.Sprintf(", ignoring %s", strings.Join(ignoring, ", "))
+	}
+	if len(m.tolerances) > 0 {
+		desc += fmt.Sprintf(", with %s", strings.Join(m.tolerances, ", "))
+	}
+	return desc
+}
+
+func (m protoEqMatcher) ExplainFailure(x any) (string, bool) {
+	got, ok := x.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not a proto message", x), true
+	}
+	diff := cmp.Diff(m.want, got, m.cmpOptions()...)
+	if diff == "" {
+		return "", false
+	}
+	return fmt.Sprintf("doesn't match (-want +got):\n%s", diff), true
+}
+
+// Returns an error if `path` doesn't name a field reachable from `md`.
+func checkFieldPath(md protoreflect.MessageDescriptor, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("%s has no field %q", md.FullName(), name)
+		}
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if i < len(names)-1 {
+			if fd.Message() == nil {
+				return fmt.Errorf("field %s isn't a message", fd.FullName())
+			}
+			md = fd.Message()
+		}
+	}
+	return nil
+}
+
+var protocmpMessageType = reflect.TypeFor[protocmp.Message]()
+
+// Returns the field names leading to the current step of `p`, skipping over
+// the elements of repeated and map fields.
+func protoFieldPath(p cmp.Path) string {
+	var names []string
+	for i := 1; i < len(p); i++ {
+		if mi, ok := p[i].(cmp.MapIndex); ok && p[i-1].Type() == protocmpMessageType {
+			names = append(names, mi.Key().String())
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+func ignoreFieldPaths(paths []string) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		if _, ok := p.Last().(cmp.MapIndex); !ok {
+			return false
+		}
+		return slices.Contains(paths, protoFieldPath(p))
+	}, cmp.Ignore())
+}
+
+// Compares messages of the type `name`, which has the seconds and nanos fields
+// of a Timestamp or Duration, with a tolerance of `margin`.
+func approxTimes(name protoreflect.FullName, margin time.Duration) cmp.Option {
+	return cmp.FilterValues(func(a, b protocmp.Message) bool {
+		return a.Descriptor().FullName() == name && b.Descriptor().FullName() == name
+	}, cmp.Comparer(func(a, b protocmp.Message) bool {
+		d := secondsAndNanos(a).Sub(secondsAndNanos(b))
+		return d.Abs() <= margin
+	}))
+}
+
+// Returns the time that's as far from the Unix epoch as the Timestamp or
+// Duration `m`, so that two of them can be subtracted.
+func secondsAndNanos(m protocmp.Message) time.Time {
+	seconds, _ := m["seconds"].(int64)
+	nanos, _ := m["nanos"].(int32)
+	return time.Unix(seconds, int64(nanos))
+}
+
+// Sorts the elements of repeated fields by their serialized form.
+func sortRepeatedFields() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		// Only repeated fields within a message: a slice, held in an interface,
+		// held in a message.
+		if t := p.Index(-1).Type(); t == nil || t.Kind() != reflect.Slice || t == reflect.TypeFor[[]byte]() {
+			return false
+		}
+		if t := p.Index(-2).Type(); t == nil || t.Kind() != reflect.Interface {
+			return false
+		}
+		return p.Index(-3).Type() == protocmpMessageType
+	}, cmp.Transformer("gotest.SortRepeated", func(v any) []any {
+		r := reflect.ValueOf(v)
+		type keyed struct {
+			key []byte
+			val any
+		}
+		elements := make([]keyed, r.Len())
+		for i := range r.Len() {
+			el := r.Index(i).Interface()
+			elements[i] = keyed{sortKey(el), el}
+		}
+		slices.SortStableFunc(elements, func(a, b keyed) int { return bytes.Compare(a.key, b.key) })
+		out := make([]any, len(elements))
+		for i, el := range elements {
+			out[i] = el.val
+		}
+		return out
+	}))
+}
+
+// Returns a key for putting values in a canonical order. Protos are ordered by
+// their serialized form, and other values by how they're printed.
+func sortKey(v any) []byte {
+	switch m := v.(type) {
+	case protocmp.Message:
+		b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m.Unwrap())
+		return b
+	case proto.Message:
+		b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		return b
+	}
+	return fmt.Appendf(nil, "%T:%v", v, v)
+}
+
+// Matches protos that are equal to the message in the prototext file at
+// `path`, which is parsed as the same type as the value. Comparison is the
+// same as ProtoEq(), and can be configured with the same ProtoOpts.
+//
+// If the test binary was run with -update, or the UpdateGoldensEnv
+// environment variable is set, the file is instead overwritten with the
+// value, and the matcher succeeds. The flag isn't defined by this package, so
+// that it doesn't clash with other golden-file helpers; define it in your test
+// package:
+//
+//	var _ = flag.Bool("update", false, "rewrite golden files")
+//
+// Examples:
+//
+//	ExpectThat(t, resp, MatchesProtoTextFile("testdata/response.textproto"))
+//	ExpectThat(t, resp, MatchesProtoTextFile("testdata/response.textproto", IgnoreFields("id")))
+func MatchesProtoTextFile(path string, opts ...ProtoOpt) Matcher {
+	return protoGoldenMatcher{path, "prototext", opts}
+}
+
+// Same as MatchesProtoTextFile(), but the file at `path` contains protojson.
+//
+// Example:
+//
+//	ExpectThat(t, resp, MatchesProtoJSONFile("testdata/response.json"))
+func MatchesProtoJSONFile(path string, opts ...ProtoOpt) Matcher {
+	return protoGoldenMatcher{path, "protojson", opts}
+}
+
+type protoGoldenMatcher struct {
+	path   string
+	format string
+	opts   []ProtoOpt
+}
+
+// Reports whether golden files should be rewritten, which is the case if the
+// test binary has an "update" flag that's set, or if UpdateGoldensEnv is set.
+func updateGoldens() bool {
+	if os.Getenv(UpdateGoldensEnv) != "" {
+		return true
+	}
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	update, _ := getter.Get().(bool)
+	return update
+}
+
+// Reads the golden file as a message of the same type as `got`, and returns a
+// matcher for it.
+func (m protoGoldenMatcher) load(got proto.Message) (Matcher, error) {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, fmt.Errorf("golden file can't be read: %w", err)
+	}
+	want := got.ProtoReflect().New().Interface()
+	if m.format == "protojson" {
+		err = protojson.Unmarshal(data, want)
+	} else {
+		err = prototext.Unmarshal(data, want)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("golden file %s isn't valid %s for %s: %w",
+			m.path, m.format, want.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return ProtoEq(want, m.opts...), nil
+}
+
+func (m protoGoldenMatcher) update(got proto.Message) error {
+	var data []byte
+	var err error
+	if m.format == "protojson" {
+		data, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(got)
+	} else {
+		data, err = prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(got)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, 0o644)
+}
+
+func (m protoGoldenMatcher) Matches(x any) bool {
+	got, ok := x.(proto.Message)
+	if !ok {
+		return false
+	}
+	if updateGoldens() {
+		return m.update(got) == nil
+	}
+	want, err := m.load(got)
+	return err == nil && want.Matches(got)
+}
+
+func (m protoGoldenMatcher) String() string {
+	return fmt.Sprintf("is a proto equal to the contents of %s", m.path)
+}
+
+func (m protoGoldenMatcher) ExplainFailure(x any) (string, bool) {
+	got, ok := x.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not a proto message", x), true
+	}
+	if updateGoldens() {
+		if err := m.update(got); err != nil {
+			return fmt.Sprintf("golden file can't be updated: %s", err), true
+		}
+		return "", false
+	}
+	want, err := m.load(got)
+	if err != nil {
+		return err.Error(), true
+	}
+	return explainMismatch(want, got), true
+}
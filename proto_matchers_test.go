@@ -0,0 +1,198 @@
+package gotest
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfmatt/gotest/testdata"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestProtoEq(t *testing.T) {
+	want := &testdata.SomeData{A: "a", I: 1, L: []string{"x", "y"}}
+	ExpectThat(t, &testdata.SomeData{A: "a", I: 1, L: []string{"x", "y"}}, ProtoEq(want))
+	ExpectThat(t, &testdata.SomeData{A: "a", I: 2, L: []string{"x", "y"}}, Not(ProtoEq(want)))
+	ExpectThat(t, &testdata.SomeData{A: "a", I: 1, L: []string{"y", "x"}}, Not(ProtoEq(want)))
+	ExpectThat(t, "a", Not(ProtoEq(want)))
+
+	r := &testReporter{}
+	ExpectThat(r, &testdata.SomeData{A: "a", I: 2, L: []string{"x", "y"}}, ProtoEq(want))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), Contains(
+		StartsWith("  Wanted: is a proto equal to "),
+		"  ...where doesn't match (-want +got):",
+		ContainsRegex(`^-.*"i":.*int32\(1\)`),
+		ContainsRegex(`^\+.*"i":.*int32\(2\)`),
+	))
+
+	r.Reset()
+	ExpectThat(r, "a", ProtoEq(want))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not a proto message"))
+}
+
+func TestProtoEq_IgnoreFields(t *testing.T) {
+	want := &testdata.SomeData{A: "a", I: 1, Recursive: &testdata.SomeData{A: "b", I: 2}}
+	got := &testdata.SomeData{A: "a", I: 100, Recursive: &testdata.SomeData{A: "b", I: 200}}
+	ExpectThat(t, got, ProtoEq(want, IgnoreFields("i", "recursive.i")))
+	ExpectThat(t, got, Not(ProtoEq(want, IgnoreFields("i"))))
+	ExpectThat(t, got, Not(ProtoEq(want, IgnoreFields("recursive.i"))))
+	ExpectThat(t, &testdata.SomeData{A: "a", I: 1}, ProtoEq(want, IgnoreFields("recursive")))
+
+	// Fields at different depths don't interfere.
+	ExpectThat(t, &testdata.SomeData{A: "x", Recursive: &testdata.SomeData{A: "b"}},
+		Not(ProtoEq(&testdata.SomeData{A: "a", Recursive: &testdata.SomeData{A: "b"}}, IgnoreFields("recursive.a"))))
+
+	ExpectThat(t, ProtoEq(want, IgnoreFields("i", "recursive.i")).String(),
+		HasSubstr("ignoring fields [i, recursive.i]"))
+
+	ExpectFatal(t, HasSubstr(`ProtoEq: can't ignore field "b": testdata.SomeData has no field "b"`), func() {
+		ProtoEq(want, IgnoreFields("b"))
+	})
+	ExpectFatal(t, HasSubstr(`ProtoEq: can't ignore field "a.b": field testdata.SomeData.a isn't a message`), func() {
+		ProtoEq(want, IgnoreFields("a.b"))
+	})
+}
+
+func TestProtoEq_IgnoreUnknownFields(t *testing.T) {
+	want := &testdata.SomeData{A: "a"}
+	got := &testdata.SomeData{A: "a"}
+	got.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 99, protowire.VarintType), 5))
+	ExpectThat(t, got, Not(ProtoEq(want)))
+	ExpectThat(t, got, ProtoEq(want, IgnoreUnknownFields()))
+	ExpectThat(t, ProtoEq(want, IgnoreUnknownFields()).String(), HasSubstr("ignoring unknown fields"))
+}
+
+func TestProtoEq_IgnoreRepeatedOrder(t *testing.T) {
+	want := &testdata.SomeData{
+		L: []string{"x", "y", "y"},
+		Recursive: &testdata.SomeData{
+			L: []string{"1", "2"},
+		},
+	}
+	ExpectThat(t, &testdata.SomeData{
+		L:         []string{"y", "x", "y"},
+		Recursive: &testdata.SomeData{L: []string{"2", "1"}},
+	}, ProtoEq(want, IgnoreRepeatedOrder()))
+	ExpectThat(t, &testdata.SomeData{
+		L:         []string{"y", "x", "x"},
+		Recursive: &testdata.SomeData{L: []string{"2", "1"}},
+	}, Not(ProtoEq(want, IgnoreRepeatedOrder())))
+
+	ExpectThat(t, ProtoEq(want, IgnoreFields("a"), IgnoreUnknownFields(), IgnoreRepeatedOrder()).String(),
+		HasSubstr("ignoring fields [a], unknown fields, repeated field order"))
+}
+
+func TestProtoEq_ApproxTimestamps(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := timestamppb.New(now)
+	ExpectThat(t, timestamppb.New(now.Add(300*time.Millisecond)), Not(ProtoEq(want)))
+	ExpectThat(t, timestamppb.New(now.Add(300*time.Millisecond)), ProtoEq(want, ApproxTimestamps(time.Second)))
+	ExpectThat(t, timestamppb.New(now.Add(-300*time.Millisecond)), ProtoEq(want, ApproxTimestamps(time.Second)))
+	ExpectThat(t, timestamppb.New(now.Add(2*time.Second)), Not(ProtoEq(want, ApproxTimestamps(time.Second))))
+	ExpectThat(t, &timestamppb.Timestamp{}, Not(ProtoEq(want, ApproxTimestamps(time.Second))))
+
+	// Nested within other messages
+	nested, err := anypb.New(want)
+	AssertNoError(t, err)
+	near, err := anypb.New(timestamppb.New(now.Add(time.Millisecond)))
+	AssertNoError(t, err)
+	ExpectThat(t, near, ProtoEq(nested, ApproxTimestamps(time.Second)))
+
+	// Durations aren't affected.
+	ExpectThat(t, durationpb.New(time.Second), Not(ProtoEq(durationpb.New(2*time.Second), ApproxTimestamps(time.Minute))))
+
+	ExpectThat(t, ProtoEq(want, IgnoreFields("nanos"), ApproxTimestamps(time.Second)).String(),
+		HasSubstr(", ignoring fields [nanos], with timestamps within 1s"))
+}
+
+func TestProtoEq_ApproxDurations(t *testing.T) {
+	want := durationpb.New(1500 * time.Millisecond)
+	ExpectThat(t, durationpb.New(1490*time.Millisecond), Not(ProtoEq(want)))
+	ExpectThat(t, durationpb.New(1490*time.Millisecond), ProtoEq(want, ApproxDurations(10*time.Millisecond)))
+	ExpectThat(t, durationpb.New(1510*time.Millisecond), ProtoEq(want, ApproxDurations(10*time.Millisecond)))
+	ExpectThat(t, durationpb.New(1520*time.Millisecond), Not(ProtoEq(want, ApproxDurations(10*time.Millisecond))))
+	ExpectThat(t, durationpb.New(-1500*time.Millisecond), Not(ProtoEq(want, ApproxDurations(time.Second))))
+
+	ExpectThat(t, ProtoEq(want, ApproxDurations(10*time.Millisecond), ApproxTimestamps(time.Second)).String(),
+		HasSubstr(", with durations within 10ms, timestamps within 1s"))
+}
+
+func TestProtoEq_ApproxFloats(t *testing.T) {
+	a, b := 0.1, 0.2
+	want := wrapperspb.Double(0.3)
+	ExpectThat(t, wrapperspb.Double(a+b), Not(ProtoEq(want)))
+	ExpectThat(t, wrapperspb.Double(a+b), ProtoEq(want, ApproxFloats(0, 1e-9)))
+	ExpectThat(t, wrapperspb.Double(0.31), Not(ProtoEq(want, ApproxFloats(0, 1e-9))))
+	ExpectThat(t, wrapperspb.Double(0.31), ProtoEq(want, ApproxFloats(0.05, 0)))
+	ExpectThat(t, wrapperspb.Float(0.31), ProtoEq(wrapperspb.Float(0.3), ApproxFloats(0, 0.02)))
+
+	// Nested within other messages
+	s, err := structpb.NewStruct(map[string]any{"score": 0.5, "labels": []any{1.0, 2.0}})
+	AssertNoError(t, err)
+	near, err := structpb.NewStruct(map[string]any{"score": 0.5001, "labels": []any{1.0001, 2.0}})
+	AssertNoError(t, err)
+	ExpectThat(t, near, Not(ProtoEq(s)))
+	ExpectThat(t, near, ProtoEq(s, ApproxFloats(0, 1e-3)))
+
+	ExpectThat(t, ProtoEq(want, ApproxFloats(0, 1e-9)).String(), HasSubstr(", with floats within 1e-09"))
+	ExpectThat(t, ProtoEq(want, ApproxFloats(0.01, 0)).String(), HasSubstr(", with floats within a fraction 0.01"))
+	ExpectThat(t, ProtoEq(want, ApproxFloats(0.01, 0.5)).String(), HasSubstr(", with floats within 0.5 or a fraction 0.01"))
+}
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestMatchesProtoTextFile(t *testing.T) {
+	golden := &testdata.SomeData{A: "hello", I: 42, L: []string{"x", "y"}, Recursive: &testdata.SomeData{A: "nested"}}
+	ExpectThat(t, golden, MatchesProtoTextFile("testdata/some_data.textproto"))
+	ExpectThat(t, golden, MatchesProtoJSONFile("testdata/some_data.json"))
+
+	changed := &testdata.SomeData{A: "hello", I: 7, L: []string{"y", "x"}, Recursive: &testdata.SomeData{A: "nested"}}
+	ExpectThat(t, changed, Not(MatchesProtoTextFile("testdata/some_data.textproto")))
+	ExpectThat(t, changed, MatchesProtoTextFile("testdata/some_data.textproto", IgnoreFields("i"), IgnoreRepeatedOrder()))
+	ExpectThat(t, changed, MatchesProtoJSONFile("testdata/some_data.json", IgnoreFields("i"), IgnoreRepeatedOrder()))
+
+	r := &testReporter{}
+	ExpectThat(r, changed, MatchesProtoTextFile("testdata/some_data.textproto"))
+	ExpectThat(t, strings.Split(r.nonFatals[0], "\n"), Contains(
+		"  Wanted: is a proto equal to the contents of testdata/some_data.textproto",
+		"  ...where doesn't match (-want +got):",
+		ContainsRegex(`^-.*"i":.*int32\(42\)`),
+	))
+
+	r.Reset()
+	ExpectThat(r, golden, MatchesProtoTextFile("testdata/missing.textproto"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where golden file can't be read: open testdata/missing.textproto: "))
+
+	r.Reset()
+	ExpectThat(r, golden, MatchesProtoTextFile("testdata/some_data.json"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where golden file testdata/some_data.json isn't valid prototext for testdata.SomeData: "))
+
+	r.Reset()
+	ExpectThat(r, "hello", MatchesProtoJSONFile("testdata/some_data.json"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type string, not a proto message"))
+}
+
+func TestMatchesProtoTextFile_Update(t *testing.T) {
+	dir := t.TempDir()
+	textPath := filepath.Join(dir, "golden.textproto")
+	jsonPath := filepath.Join(dir, "golden.json")
+	msg := &testdata.SomeData{A: "new", L: []string{"z"}}
+	ExpectThat(t, msg, Not(MatchesProtoTextFile(textPath)))
+
+	*update = true
+	defer func() { *update = false }()
+	ExpectThat(t, msg, MatchesProtoTextFile(textPath))
+	ExpectThat(t, msg, MatchesProtoJSONFile(jsonPath))
+	*update = false
+
+	ExpectThat(t, msg, MatchesProtoTextFile(textPath))
+	ExpectThat(t, msg, MatchesProtoJSONFile(jsonPath))
+	ExpectThat(t, &testdata.SomeData{A: "old"}, Not(MatchesProtoJSONFile(jsonPath)))
+}
@@ -0,0 +1,83 @@
+package gotest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// Returns how long to wait before the given retry, counting from 1. See
+// ExpectWithRetry().
+type Backoff func(retry int) time.Duration
+
+// Waits `delay` before every retry.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// Waits `initial` before the first retry, and twice as long before each one
+// after that, up to `limit`.
+func ExponentialBackoff(initial, limit time.Duration) Backoff {
+	return func(retry int) time.Duration {
+		delay := initial
+		for i := 1; i < retry && delay < limit; i++ {
+			delay *= 2
+		}
+		return min(delay, limit)
+	}
+}
+
+// Randomizes the delays of `backoff` by up to `fraction` of each in either
+// direction, so that concurrent retries don't all happen at once.
+//
+// Example:
+//
+//	WithJitter(ExponentialBackoff(10*time.Millisecond, time.Second), 0.2)
+func WithJitter(backoff Backoff, fraction float64) Backoff {
+	return func(retry int) time.Duration {
+		delay := backoff(retry)
+		return delay + time.Duration((rand.Float64()*2-1)*fraction*float64(delay))
+	}
+}
+
+// Calls `f` up to `attempts` times, until its result fulfills `matcher`,
+// waiting between attempts as determined by `backoff`. If no result matches,
+// causes the test to fail, explaining each attempt.
+//
+// This is meant for checks against eventually-consistent systems outside the
+// test. Unlike WithContext().Eventually(), the number of attempts is bounded,
+// rather than the time taken.
+//
+// Example:
+//
+//	ExpectWithRetry(t, 5, ExponentialBackoff(100*time.Millisecond, 2*time.Second),
+//		func() any { return store.Get(key) }, "updated")
+func ExpectWithRetry(t gomock.TestHelper, attempts int, backoff Backoff, f func() any, matcher any) bool {
+	t.Helper()
+	c := configFor(t)
+	m := c.configure(AsMatcher(matcher))
+	limits := outputLimitsOf(m)
+	var failures []string
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(backoff(attempt - 1))
+		}
+		val := f()
+		if m.Matches(val) {
+			return true
+		}
+		failure := appendExplanation("got "+formatGot(val, m), m, val)
+		failures = append(failures, fmt.Sprintf("  Attempt %d: %s", attempt, truncate(failure, limits)))
+	}
+	explanation := fmt.Sprintf("Expectation failed after %d attempts:\n  Wanted: %s", attempts, m.String())
+	if msg := failureMessageOf(m); msg != "" {
+		explanation += fmt.Sprintf("\n  Message: %s", msg)
+	}
+	c.fail(t, false, explanation+"\n"+strings.Join(failures, "\n"))
+	return false
+}
@@ -0,0 +1,67 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	ExpectEq(t, ConstantBackoff(time.Second)(1), time.Second)
+	ExpectEq(t, ConstantBackoff(time.Second)(5), time.Second)
+
+	exponential := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	ExpectEq(t, exponential(1), 10*time.Millisecond)
+	ExpectEq(t, exponential(2), 20*time.Millisecond)
+	ExpectEq(t, exponential(3), 40*time.Millisecond)
+	ExpectEq(t, exponential(4), 50*time.Millisecond)
+	ExpectEq(t, exponential(100), 50*time.Millisecond)
+
+	jittered := WithJitter(ConstantBackoff(time.Second), 0.1)
+	for range 100 {
+		ExpectThat(t, jittered(1), Within(900*time.Millisecond, 1100*time.Millisecond, IncludeHigh()))
+	}
+}
+
+func TestExpectWithRetry(t *testing.T) {
+	r := testReporter{}
+	calls := 0
+	count := func() any {
+		calls++
+		return calls
+	}
+	ExpectEq(t, ExpectWithRetry(&r, 5, ConstantBackoff(time.Millisecond), count, 3), true)
+	ExpectEq(t, calls, 3)
+	ExpectEq(t, r.HasFailures(), false)
+
+	calls = 0
+	ExpectEq(t, ExpectWithRetry(&r, 3, ConstantBackoff(time.Millisecond), count, Gt(5)), false)
+	ExpectEq(t, calls, 3)
+	ExpectEq(t, r.nonFatals, []string{strings.Join([]string{
+		"Expectation failed after 3 attempts:",
+		"  Wanted: is greater than 5 (int)",
+		"  Attempt 1: got 1 (int)",
+		"  Attempt 2: got 2 (int)",
+		"  Attempt 3: got 3 (int)",
+	}, "\n")})
+
+	r.Reset()
+	ExpectWithRetry(&r, 2, ConstantBackoff(0), func() any { return "abc" }, Len(2))
+	ExpectThat(t, r.nonFatals, ElementsAre(HasSubstr("\n  Attempt 2: got abc (string), where length is 3")))
+
+	// Messages and output limits apply as in other assertions.
+	r.Reset()
+	ExpectWithRetry(&r, 2, ConstantBackoff(0), func() any { return "a\nb\nc" },
+		WithOutputLimits(WithMessage(Len(2), "cache not warmed"), OutputLimits{MaxLines: 2}))
+	ExpectEq(t, r.nonFatals, []string{strings.Join([]string{
+		"Expectation failed after 2 attempts:",
+		"  Wanted: has length which is equal to 2 (int)",
+		"  Message: cache not warmed",
+		"  Attempt 1: got a",
+		"b",
+		"... 1 more lines truncated",
+		"  Attempt 2: got a",
+		"b",
+		"... 1 more lines truncated",
+	}, "\n")})
+}
@@ -0,0 +1,155 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Matches sets whose members fulfill `elements`, with each element matching a
+// different member and no members left over.
+//
+// Sets are maps with values of type struct{} or bool. For map[T]bool, only
+// the keys mapped to true are members.
+//
+// Examples:
+//
+//	ExpectThat(t, map[string]struct{}{"a": {}, "b": {}}, SetIs("a", "b"))
+//	ExpectThat(t, map[int]bool{1: true, 2: true, 3: false}, SetIs(1, 2))
+//	ExpectThat(t, map[string]bool{"x1": true}, SetIs(StartsWith("x")))
+func SetIs(elements ...any) Matcher {
+	return newSetMatcher(elements, unorderedMatcher{matchAll: true})
+}
+
+// Matches sets that have a distinct member fulfilling each of `elements`, and
+// potentially other members as well.
+//
+// See SetIs() for which maps are considered sets.
+//
+// Examples:
+//
+//	s := map[string]struct{}{"a": {}, "b": {}, "c": {}}
+//	ExpectThat(t, s, SetContains("a", "c"))
+//	ExpectThat(t, s, Not(SetContains("d")))
+func SetContains(elements ...any) Matcher {
+	return newSetMatcher(elements, unorderedMatcher{})
+}
+
+// Matches sets whose members each fulfill a different one of `elements`. Not
+// all of `elements` need to be used.
+//
+// See SetIs() for which maps are considered sets.
+//
+// Examples:
+//
+//	allowed := []any{"read", "write", "admin"}
+//	ExpectThat(t, map[string]bool{"read": true, "write": true}, SetSubsetOf(allowed...))
+//	ExpectThat(t, map[string]bool{"delete": true}, Not(SetSubsetOf(allowed...)))
+func SetSubsetOf(elements ...any) Matcher {
+	return newSetMatcher(elements, unorderedMatcher{subset: true})
+}
+
+func newSetMatcher(elements []any, m unorderedMatcher) setMatcher {
+	m.elements = make([]Matcher, len(elements))
+	for i, el := range elements {
+		m.elements[i] = AsMatcher(el)
+	}
+	return setMatcher{m}
+}
+
+type setMatcher struct {
+	elements unorderedMatcher
+}
+
+// Returns the members of the set `x` as a slice, sorted if they're of an
+// ordered type.
+func setMembers(x any) (any, error) {
+	r := reflect.ValueOf(x)
+	if r.Kind() != reflect.Map {
+		return nil, fmt.Errorf("type %T isn't a set", x)
+	}
+	elem := r.Type().Elem()
+	isBool := elem.Kind() == reflect.Bool
+	if !isBool && (elem.Kind() != reflect.Struct || elem.NumField() != 0) {
+		return nil, fmt.Errorf("type %T isn't a set, since its values aren't struct{} or bool", x)
+	}
+
+	members := reflect.MakeSlice(reflect.SliceOf(r.Type().Key()), 0, r.Len())
+	for _, k := range sortedMapKeys(r) {
+		if !isBool || r.MapIndex(k).Bool() {
+			members = reflect.Append(members, k)
+		}
+	}
+	return members.Interface(), nil
+}
+
+func (m setMatcher) Matches(x any) bool {
+	members, err := setMembers(x)
+	return err == nil && m.elements.Matches(members)
+}
+
+func (m setMatcher) String() string {
+	elemStrings := make([]string, len(m.elements.elements))
+	for i, el := range m.elements.elements {
+		elemStrings[i] = el.String()
+	}
+	var prefix string
+	if m.elements.matchAll {
+		prefix = "is a set with members matching"
+	} else if m.elements.subset {
+		prefix = "is a set with members each matching a different one of"
+	} else {
+		prefix = "is a set containing members matching"
+	}
+	return fmt.Sprintf("%s [%s]", prefix, strings.Join(elemStrings, "; "))
+}
+
+func (m setMatcher) ExplainFailure(x any) (string, bool) {
+	members, err := setMembers(x)
+	if err != nil {
+		return err.Error(), true
+	}
+	r := reflect.ValueOf(members)
+
+	// Report elements which are missing or unexpected outright, since that's
+	// usually the whole story.
+	var problems []string
+	if !m.elements.subset {
+		for _, matcher := range m.elements.elements {
+			found := false
+			for i := range r.Len() {
+				if matcher.Matches(r.Index(i).Interface()) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				problems = append(problems, fmt.Sprintf("no member %s", matcher.String()))
+			}
+		}
+	}
+	if m.elements.matchAll || m.elements.subset {
+		var extra []any
+		for i := range r.Len() {
+			member := r.Index(i).Interface()
+			found := false
+			for _, matcher := range m.elements.elements {
+				if matcher.Matches(member) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				extra = append(extra, member)
+			}
+		}
+		if len(extra) > 0 {
+			problems = append(problems, fmt.Sprintf("unexpected members %v", extra))
+		}
+	}
+	if len(problems) > 0 {
+		return strings.Join(problems, "; "), true
+	}
+
+	return appendExplanation(fmt.Sprintf("members are %v", members), m.elements, members), true
+}
@@ -0,0 +1,82 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetIs(t *testing.T) {
+	ExpectThat(t, map[string]struct{}{"a": {}, "b": {}}, SetIs("a", "b"))
+	ExpectThat(t, map[string]struct{}{"a": {}, "b": {}}, SetIs("b", "a"))
+	ExpectThat(t, map[int]bool{1: true, 2: true, 3: false}, SetIs(1, 2))
+	ExpectThat(t, map[string]bool{"x1": true}, SetIs(StartsWith("x")))
+	ExpectThat(t, map[string]struct{}{}, SetIs())
+	ExpectThat(t, map[string]struct{}{"a": {}}, Not(SetIs("a", "b")))
+	ExpectThat(t, map[int]bool{1: true, 2: true}, Not(SetIs(1)))
+	ExpectThat(t, map[string]int{"a": 1}, Not(SetIs("a")))
+	ExpectThat(t, []string{"a"}, Not(SetIs("a")))
+
+	r := &testReporter{}
+	ExpectThat(r, map[string]struct{}{"a": {}, "d": {}, "e": {}}, SetIs("a", "b", "c"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a set with members matching [is equal to a (string); is equal to b (string); is equal to c (string)]",
+		"  Got: map[a:{} d:{} e:{}] (map[string]struct {})",
+		"  ...where no member is equal to b (string); no member is equal to c (string); unexpected members [d e]",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(r, map[int]bool{1: true, 2: false}, SetIs(1, 2))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where no member is equal to 2 (int)"))
+
+	r.Reset()
+	ExpectThat(r, map[string]bool{"ab": true, "c": true}, SetIs(StartsWith("a"), HasSubstr("b")))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where unexpected members [c]"))
+
+	r.Reset()
+	ExpectThat(r, map[string]bool{"ab": true, "c": true}, SetIs(HasSubstr("a"), HasSubstr("b"), "c"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where members are [ab c], where 3 elements expected but got 2"))
+
+	r.Reset()
+	ExpectThat(r, map[string]int{"a": 1}, SetIs("a"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type map[string]int isn't a set, since its values aren't struct{} or bool"))
+
+	r.Reset()
+	ExpectThat(r, []string{"a"}, SetIs("a"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where type []string isn't a set"))
+}
+
+func TestSetContains(t *testing.T) {
+	s := map[string]struct{}{"a": {}, "b": {}, "c": {}}
+	ExpectThat(t, s, SetContains("a", "c"))
+	ExpectThat(t, s, SetContains())
+	ExpectThat(t, map[string]bool{"a": true, "b": false}, Not(SetContains("b")))
+	ExpectThat(t, s, Not(SetContains("d")))
+
+	r := &testReporter{}
+	ExpectThat(r, s, SetContains("a", "d"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a set containing members matching [is equal to a (string); is equal to d (string)]",
+		"  Got: map[a:{} b:{} c:{}] (map[string]struct {})",
+		"  ...where no member is equal to d (string)",
+	}, "\n"))
+}
+
+func TestSetSubsetOf(t *testing.T) {
+	allowed := []any{"read", "write", "admin"}
+	ExpectThat(t, map[string]bool{"read": true, "write": true}, SetSubsetOf(allowed...))
+	ExpectThat(t, map[string]bool{"delete": false}, SetSubsetOf(allowed...))
+	ExpectThat(t, map[string]struct{}{}, SetSubsetOf(allowed...))
+	ExpectThat(t, map[string]bool{"delete": true}, Not(SetSubsetOf(allowed...)))
+
+	r := &testReporter{}
+	ExpectThat(r, map[string]bool{"read": true, "delete": true}, SetSubsetOf(allowed...))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is a set with members each matching a different one of " +
+			"[is equal to read (string); is equal to write (string); is equal to admin (string)]",
+		"  Got: map[delete:true read:true] (map[string]bool)",
+		"  ...where unexpected members [delete]",
+	}, "\n"))
+}
@@ -1,9 +1,16 @@
 package gotest
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // Matches strings and byte-arrays that start with the given prefix.
@@ -65,19 +72,34 @@ func (m substrMatcher) String() string {
 // is stricter by default; gomock.Regex has the behavior of our
 // ContainsRegex().
 //
+// Flags can be set with options like IgnoreCase(), rather than by editing the
+// pattern. Even with MultiLine(), the regexp must match the entire value.
+//
 // Examples:
 //
 //	ExpectThat(t, "hello", Regex("hello"))
 //	ExpectThat(t, "hello", Regex("\\w+"))
 //	ExpectThat(t, "hello, world", Not(Regex("\\w+")))
-func Regex(r string) Matcher {
+//	ExpectThat(t, "Hello", Regex("hello", IgnoreCase()))
+func Regex(r string, opts ...RegexOption) Matcher {
 	if len(r) == 0 || r[0] != '^' {
 		r = "^" + r
 	}
 	if r[len(r)-1] != '$' {
 		r = r + "$"
 	}
-	return regexMatcher{r: regexp.MustCompile(r)}
+	flags := applyRegexOptions(opts)
+	compiled := r
+	if flags.multiLine {
+		// In multi-line mode, ^ and $ match at line breaks too, so anchor to
+		// the start and end of the text explicitly.
+		compiled = `\A(?:` + r + `)\z`
+	}
+	return regexMatcher{
+		r:       regexp.MustCompile(flags.prefix() + compiled),
+		pattern: r,
+		flags:   flags,
+	}
 }
 
 // Matches strings and byte-arrays that contain a match for the given
@@ -93,13 +115,181 @@ func Regex(r string) Matcher {
 //	ExpectThat(t, "hello", ContainsRegex("\\w+"))
 //	ExpectThat(t, "hello, world", ContainsRegex("\\w+"))
 //	ExpectThat(t, "hello, world", Not(ContainsRegex("\\d")))
-func ContainsRegex(r string) Matcher {
-	return regexMatcher{r: regexp.MustCompile(r)}
+//	ExpectThat(t, "a\nerror: x", ContainsRegex("^error:", MultiLine()))
+func ContainsRegex(r string, opts ...RegexOption) Matcher {
+	flags := applyRegexOptions(opts)
+	return regexMatcher{
+		r:       regexp.MustCompile(flags.prefix() + r),
+		pattern: r,
+		flags:   flags,
+	}
+}
+
+// Sets flags for Regex() and ContainsRegex().
+type RegexOption func(*regexFlags)
+
+// Matches letters regardless of case, like the `i` flag.
+func IgnoreCase() RegexOption {
+	return func(f *regexFlags) { f.ignoreCase = true }
+}
+
+// Lets `.` match newlines, like the `s` flag.
+func DotAll() RegexOption {
+	return func(f *regexFlags) { f.dotAll = true }
+}
+
+// Lets `^` and `$` match at the start and end of each line, like the `m`
+// flag.
+func MultiLine() RegexOption {
+	return func(f *regexFlags) { f.multiLine = true }
+}
+
+type regexFlags struct {
+	ignoreCase bool
+	dotAll     bool
+	multiLine  bool
+}
+
+func applyRegexOptions(opts []RegexOption) regexFlags {
+	var flags regexFlags
+	for _, opt := range opts {
+		opt(&flags)
+	}
+	return flags
+}
+
+// Returns the flag group to prepend to the pattern, if any.
+func (f regexFlags) prefix() string {
+	var flags string
+	if f.ignoreCase {
+		flags += "i"
+	}
+	if f.dotAll {
+		flags += "s"
+	}
+	if f.multiLine {
+		flags += "m"
+	}
+	if flags == "" {
+		return ""
+	}
+	return "(?" + flags + ")"
+}
+
+func (f regexFlags) String() string {
+	var names []string
+	if f.ignoreCase {
+		names = append(names, "case-insensitive")
+	}
+	if f.dotAll {
+		names = append(names, "dot matches newline")
+	}
+	if f.multiLine {
+		names = append(names, "multi-line")
+	}
+	return strings.Join(names, ", ")
+}
+
+// Matches strings and byte-arrays that contain a match for the given regexp,
+// where each capture group's text fulfills the corresponding element of
+// `groups`. Like ContainsRegex(), the regexp can match any part of the value;
+// only the first match is checked.
+//
+// Each element of `groups` can be a matcher or an exact value, which the
+// captured text (a string) must fulfill. To compare a group as a number, wrap
+// its matcher with ParsesAsInt() or ParsesAsFloat(); a failure whose matcher
+// would have matched the number points this out.
+//
+// Panics if the regexp is malformed, or has fewer capture groups than
+// `groups`.
+//
+// Examples:
+//
+//	ExpectThat(t, "login user=123", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+//	ExpectThat(t, "a=1, b=x", RegexCaptures(`a=(\d), b=(\w)`, "1", "x"))
+//	ExpectThat(t, "user=42", Not(RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100)))))
+func RegexCaptures(pattern string, groups ...any) Matcher {
+	r := regexp.MustCompile(pattern)
+	if len(groups) > r.NumSubexp() {
+		panic(fmt.Sprintf("RegexCaptures: regex '%s' has %d capture groups, but %d matchers were given",
+			pattern, r.NumSubexp(), len(groups)))
+	}
+	return captureMatcher{r: r, groups: asMatchers(groups)}
+}
+
+type captureMatcher struct {
+	stringMatcher
+	r      *regexp.Regexp
+	groups []Matcher
+}
+
+// Returns the captured text of each group, or nil if there's no match.
+func (m captureMatcher) captures(x any) []string {
+	if asStr, ok := m.getString(x); ok {
+		return m.r.FindStringSubmatch(asStr)
+	}
+	return nil
+}
+
+func (m captureMatcher) Matches(x any) bool {
+	captures := m.captures(x)
+	if captures == nil {
+		return false
+	}
+	for i, group := range m.groups {
+		if !group.Matches(captures[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m captureMatcher) String() string {
+	return fmt.Sprintf("matches regex '%s' with groups [%s]", m.r, joinMatcherStrings(m.groups))
+}
+
+func (m captureMatcher) ExplainFailure(x any) (string, bool) {
+	if _, ok := m.getString(x); !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	captures := m.captures(x)
+	if captures == nil {
+		return "regex doesn't match", true
+	}
+	parts := make([]string, 0)
+	for i, group := range m.groups {
+		if !group.Matches(captures[i+1]) {
+			part := fmt.Sprintf("group %d is '%s': %s", i+1, captures[i+1], explainMismatch(group, captures[i+1]))
+			if wrapper := numericWrapperFor(group, captures[i+1]); wrapper != "" {
+				part += fmt.Sprintf(" (the captured text is a string; wrap the matcher with %s to compare it as a number)", wrapper)
+			}
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return strings.Join(parts, "; "), true
+}
+
+// Returns the wrapper, ParsesAsInt() or ParsesAsFloat(), under which `m`
+// would match the number in `s`, or "" if there's none. A numeric matcher like
+// Gt(100) never matches the captured text itself, which is a string.
+func numericWrapperFor(m Matcher, s string) string {
+	if n, err := strconv.Atoi(s); err == nil && m.Matches(n) {
+		return "ParsesAsInt()"
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && m.Matches(f) {
+		return "ParsesAsFloat()"
+	}
+	return ""
 }
 
 type regexMatcher struct {
 	stringMatcher
-	r *regexp.Regexp
+	r       *regexp.Regexp
+	pattern string
+	flags   regexFlags
 }
 
 func (r regexMatcher) Matches(x any) bool {
@@ -113,7 +303,542 @@ func (r regexMatcher) Matches(x any) bool {
 }
 
 func (r regexMatcher) String() string {
-	return fmt.Sprintf("matches regex '%s'", r.r)
+	if flags := r.flags.String(); flags != "" {
+		return fmt.Sprintf("matches regex '%s' (%s)", r.pattern, flags)
+	}
+	return fmt.Sprintf("matches regex '%s'", r.pattern)
+}
+
+// Matches strings and byte-arrays that are equal to `s`, ignoring case.
+// Comparison is Unicode-aware, using the same case folding as
+// strings.EqualFold.
+//
+// Examples:
+//
+//	ExpectThat(t, "Hello", EqualIgnoringCase("hello"))
+//	ExpectThat(t, []byte("ΣΑΣ"), EqualIgnoringCase("σας"))
+//	ExpectThat(t, "hello", Not(EqualIgnoringCase("help")))
+func EqualIgnoringCase(s string) Matcher {
+	return foldMatcher{s: s}
+}
+
+type foldMatcher struct {
+	stringMatcher
+	s string
+}
+
+func (m foldMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		return strings.EqualFold(asStr, m.s)
+	} else {
+		return false
+	}
+}
+
+func (m foldMatcher) String() string {
+	return fmt.Sprintf("is equal to '%s' (ignoring case)", m.s)
+}
+
+func (m foldMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+
+	// Find the first character that differs
+	pos := 0
+	for a, b := asStr, m.s; ; pos++ {
+		if a == "" && b == "" {
+			return "", false
+		} else if a == "" {
+			return fmt.Sprintf("value is too short; it ends at character %d", pos), true
+		} else if b == "" {
+			return fmt.Sprintf("value is too long; it has extra text at character %d", pos), true
+		}
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if !strings.EqualFold(a[:na], b[:nb]) {
+			return fmt.Sprintf("first difference is at character %d ('%c' vs. '%c')", pos, ra, rb), true
+		}
+		a, b = a[na:], b[nb:]
+	}
+}
+
+// Matches strings and byte-arrays that are equal to `s` after collapsing
+// whitespace: leading and trailing whitespace is removed, and every other run
+// of whitespace is treated as a single space. Useful for comparing generated
+// code, SQL, or templated text.
+//
+// Examples:
+//
+//	ExpectThat(t, "SELECT *\n  FROM users ", EqualIgnoringWhitespace("SELECT * FROM users"))
+//	ExpectThat(t, "a  b", EqualIgnoringWhitespace(" a\tb\n"))
+//	ExpectThat(t, "ab", Not(EqualIgnoringWhitespace("a b")))
+func EqualIgnoringWhitespace(s string) Matcher {
+	return normalizedMatcher{
+		s:         s,
+		normalize: collapseWhitespace,
+		desc:      "ignoring whitespace",
+	}
+}
+
+// Matches strings and byte-arrays that are equal to `s` after removing leading
+// and trailing whitespace from both. Whitespace within the strings must match
+// exactly.
+//
+// Examples:
+//
+//	ExpectThat(t, "  hello\n", EqualTrimmed("hello"))
+//	ExpectThat(t, "hello  world", Not(EqualTrimmed("hello world")))
+func EqualTrimmed(s string) Matcher {
+	return normalizedMatcher{
+		s:         s,
+		normalize: strings.TrimSpace,
+		desc:      "ignoring leading and trailing whitespace",
+	}
+}
+
+// Matches strings and byte-arrays that are canonically equivalent to `s`
+// under Unicode: both are converted to Normalization Form C before they're
+// compared. This way, "é" written as a single code point (U+00E9) is equal to
+// "e" followed by a combining accent (U+0065 U+0301), as produced by some
+// platforms and input methods.
+//
+// Examples:
+//
+//	ExpectThat(t, "Cafe\u0301", EqNFC("Caf\u00e9"))
+//	ExpectThat(t, filename, EqNFC("résumé.pdf"))
+//	ExpectThat(t, "Cafe", Not(EqNFC("Café")))
+func EqNFC(s string) Matcher {
+	return normalizedMatcher{
+		s:         s,
+		normalize: norm.NFC.String,
+		desc:      "after Unicode normalization",
+	}
+}
+
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Matches strings that are equal to `s` after applying `normalize` to both.
+type normalizedMatcher struct {
+	stringMatcher
+	s         string
+	normalize func(string) string
+	desc      string
+}
+
+func (m normalizedMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		return m.normalize(asStr) == m.normalize(m.s)
+	} else {
+		return false
+	}
+}
+
+func (m normalizedMatcher) String() string {
+	return fmt.Sprintf("is equal to '%s' (%s)", m.s, m.desc)
+}
+
+func (m normalizedMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	return fmt.Sprintf("normalized value is '%s', but wanted '%s'",
+		m.normalize(asStr), m.normalize(m.s)), true
+}
+
+// Matches strings and byte-arrays that are exactly equal to `s`, comparing
+// them line by line. This is meant for large or generated multi-line text:
+// on failure, rather than printing both strings in full, it shows a
+// line-numbered diff of just the lines that differ and a little context
+// around them.
+//
+// Examples:
+//
+//	ExpectThat(t, renderTemplate(data), LinesEq(string(golden)))
+//	ExpectThat(t, "a\nb\nc", LinesEq("a\nb\nc"))
+//	ExpectThat(t, "a\nB\nc", Not(LinesEq("a\nb\nc")))
+func LinesEq(s string) Matcher {
+	return linesMatcher{s: s}
+}
+
+type linesMatcher struct {
+	stringMatcher
+	s string
+}
+
+func (m linesMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		return asStr == m.s
+	} else {
+		return false
+	}
+}
+
+func (m linesMatcher) String() string {
+	lines := strings.Split(m.s, "\n")
+	if len(lines) == 1 {
+		return fmt.Sprintf("is equal to '%s'", m.s)
+	}
+	return fmt.Sprintf("is equal to the %d lines starting with '%s'", len(lines), lines[0])
+}
+
+func (m linesMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	if asStr == m.s {
+		return "", false
+	}
+	return "lines differ (-want +got):" + formatLineDiff(
+		diffLines(strings.Split(m.s, "\n"), strings.Split(asStr, "\n"))), true
+}
+
+// Matches strings and byte-arrays that contain all of the given substrings,
+// in the given order and without overlapping. Other text may appear before,
+// between, and after them.
+//
+// Examples:
+//
+//	ExpectThat(t, logs, ContainsInOrder("starting", "connected", "shutting down"))
+//	ExpectThat(t, "abcabc", ContainsInOrder("c", "a"))
+//	ExpectThat(t, "abc", Not(ContainsInOrder("b", "a")))
+func ContainsInOrder(substrs ...string) Matcher {
+	return inOrderMatcher{substrs: substrs}
+}
+
+type inOrderMatcher struct {
+	stringMatcher
+	substrs []string
+}
+
+// Returns the index of the first substring that couldn't be found, and the
+// offset at which the search for it started; or -1 if all were found.
+func (m inOrderMatcher) search(s string) (missing, offset int) {
+	for i, sub := range m.substrs {
+		idx := strings.Index(s[offset:], sub)
+		if idx < 0 {
+			return i, offset
+		}
+		offset += idx + len(sub)
+	}
+	return -1, offset
+}
+
+func (m inOrderMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		missing, _ := m.search(asStr)
+		return missing < 0
+	} else {
+		return false
+	}
+}
+
+func (m inOrderMatcher) String() string {
+	quoted := make([]string, len(m.substrs))
+	for i, sub := range m.substrs {
+		quoted[i] = fmt.Sprintf("'%s'", sub)
+	}
+	return fmt.Sprintf("contains substrings in order [%s]", strings.Join(quoted, ", "))
+}
+
+func (m inOrderMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	missing, offset := m.search(asStr)
+	if missing < 0 {
+		return "", false
+	}
+	if missing == 0 {
+		return fmt.Sprintf("substring 0 ('%s') wasn't found", m.substrs[0]), true
+	}
+	return fmt.Sprintf("substring %d ('%s') wasn't found after character %d, where substring %d ended",
+		missing, m.substrs[missing], offset, missing-1), true
+}
+
+// Matches strings and byte-arrays that match the given glob pattern. This is
+// a lighter-weight alternative to Regex(), particularly for paths.
+//
+// The pattern syntax is the same as path.Match:
+//   - `*` matches any sequence of characters other than '/'
+//   - `?` matches any single character other than '/'
+//   - `[abc]`, `[a-z]`, and `[^a-z]` match character classes
+//   - `\` escapes the following character
+//
+// The entire value must match the pattern. Panics if the pattern is
+// malformed.
+//
+// Examples:
+//
+//	ExpectThat(t, "logs/app.log", MatchesGlob("logs/*.log"))
+//	ExpectThat(t, "file1.txt", MatchesGlob("file[0-9].txt"))
+//	ExpectThat(t, "logs/old/app.log", Not(MatchesGlob("logs/*.log")))
+func MatchesGlob(pattern string) Matcher {
+	if _, err := path.Match(pattern, ""); err != nil {
+		panic(fmt.Sprintf("MatchesGlob: invalid pattern '%s': %s", pattern, err))
+	}
+	return globMatcher{pattern: pattern}
+}
+
+type globMatcher struct {
+	stringMatcher
+	pattern string
+}
+
+func (m globMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		matched, _ := path.Match(m.pattern, asStr)
+		return matched
+	} else {
+		return false
+	}
+}
+
+func (m globMatcher) String() string {
+	return fmt.Sprintf("matches glob '%s'", m.pattern)
+}
+
+// Matches strings and byte-arrays that are entirely valid UTF-8.
+//
+// Examples:
+//
+//	ExpectThat(t, sanitize(input), ValidUTF8())
+//	ExpectThat(t, []byte("h\xffi"), Not(ValidUTF8()))
+func ValidUTF8() Matcher {
+	return utf8Matcher{}
+}
+
+type utf8Matcher struct {
+	stringMatcher
+}
+
+func (m utf8Matcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		return utf8.ValidString(asStr)
+	} else {
+		return false
+	}
+}
+
+func (m utf8Matcher) String() string {
+	return "is valid UTF-8"
+}
+
+func (m utf8Matcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	for i := 0; i < len(asStr); {
+		r, size := utf8.DecodeRuneInString(asStr[i:])
+		if r == utf8.RuneError && size == 1 {
+			return fmt.Sprintf("byte %d (0x%02x) isn't part of a valid UTF-8 sequence", i, asStr[i]), true
+		}
+		i += size
+	}
+	return "", false
+}
+
+// Matches strings and byte-arrays which, as a slice of runes, fulfill
+// `inner`. This lets container matchers like ElementsAre(), Contains() and
+// Each() look at the individual characters of a string.
+//
+// The elements are of type rune, so `inner` should compare against runes
+// (e.g. 'a' rather than "a").
+//
+// Examples:
+//
+//	isDigit := Untyped(MatcherFuncT("is a digit", unicode.IsDigit))
+//	ExpectThat(t, "12345", Runes(Each(isDigit)))
+//	ExpectThat(t, "hello World", Runes(Contains(Untyped(MatcherFuncT("is upper case", unicode.IsUpper)))))
+//	ExpectThat(t, "héllo", Runes(ElementsAre('h', 'é', 'l', 'l', 'o')))
+func Runes(inner any) Matcher {
+	return runesMatcher{inner: AsMatcher(inner)}
+}
+
+type runesMatcher struct {
+	stringMatcher
+	inner Matcher
+}
+
+func (m runesMatcher) Matches(x any) bool {
+	if asStr, ok := m.getString(x); ok {
+		return m.inner.Matches([]rune(asStr))
+	} else {
+		return false
+	}
+}
+
+func (m runesMatcher) String() string {
+	return fmt.Sprintf("has runes which %s", m.inner.String())
+}
+
+func (m runesMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	runes := []rune(asStr)
+	explanation := fmt.Sprintf("runes are %q", runes)
+	explanation = appendExplanation(explanation, m.inner, runes)
+	return explanation, true
+}
+
+// Matches strings and byte-arrays containing base64 which, once decoded,
+// fulfills `inner`. Both the standard and URL-safe alphabets are accepted,
+// with or without padding.
+//
+// The decoded value is a []byte, so `inner` should be a []byte or a matcher
+// that accepts byte-arrays, like HasSubstr or JSONEq.
+//
+// Examples:
+//
+//	ExpectThat(t, "aGVsbG8=", Base64DecodesTo([]byte("hello")))
+//	ExpectThat(t, token, Base64DecodesTo(JSONMatches(MapContains(map[string]any{"sub": "alice"}))))
+//	ExpectThat(t, signature, Base64DecodesTo(Len(64)))
+func Base64DecodesTo(inner any) Matcher {
+	return decodingMatcher{
+		inner:    AsMatcher(inner),
+		encoding: "base64",
+		decode:   decodeBase64,
+	}
+}
+
+// Matches strings and byte-arrays containing hexadecimal which, once decoded,
+// fulfills `inner`. Both upper- and lower-case digits are accepted.
+//
+// The decoded value is a []byte, so `inner` should be a []byte or a matcher
+// that accepts byte-arrays.
+//
+// Examples:
+//
+//	ExpectThat(t, "cafe", HexDecodesTo([]byte{0xca, 0xfe}))
+//	ExpectThat(t, sha256Hex, HexDecodesTo(Len(32)))
+func HexDecodesTo(inner any) Matcher {
+	return decodingMatcher{
+		inner:    AsMatcher(inner),
+		encoding: "hex",
+		decode:   hex.DecodeString,
+	}
+}
+
+// Decodes base64 in any of the standard variants.
+func decodeBase64(s string) ([]byte, error) {
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	return enc.DecodeString(strings.TrimRight(s, "="))
+}
+
+type decodingMatcher struct {
+	stringMatcher
+	inner    Matcher
+	encoding string
+	decode   func(string) ([]byte, error)
+}
+
+func (m decodingMatcher) Matches(x any) bool {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return false
+	}
+	decoded, err := m.decode(asStr)
+	if err != nil {
+		return false
+	}
+	return m.inner.Matches(decoded)
+}
+
+func (m decodingMatcher) String() string {
+	return fmt.Sprintf("when %s-decoded, %s", m.encoding, m.inner.String())
+}
+
+func (m decodingMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	decoded, err := m.decode(asStr)
+	if err != nil {
+		return fmt.Sprintf("value isn't valid %s: %s", m.encoding, err), true
+	}
+	explanation := fmt.Sprintf("decoded value is %s", formatGot(decoded, m.inner))
+	explanation = appendExplanation(explanation, m.inner, decoded)
+	return explanation, true
+}
+
+// Matches strings and byte-arrays containing a decimal integer, such as
+// "-42", whose value, as an int, fulfills `inner`.
+//
+// Examples:
+//
+//	ExpectThat(t, "123", ParsesAsInt(Gt(100)))
+//	ExpectThat(t, "login user=123", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+func ParsesAsInt(inner any) Matcher {
+	return parsingMatcher{
+		inner: AsMatcher(inner),
+		kind:  "an int",
+		parse: func(s string) (any, error) { return strconv.Atoi(s) },
+	}
+}
+
+// Matches strings and byte-arrays containing a number, such as "1.5" or
+// "2e-3", whose value, as a float64, fulfills `inner`.
+//
+// Examples:
+//
+//	ExpectThat(t, "1.5", ParsesAsFloat(Near(1.5, 0.01)))
+//	ExpectThat(t, "took 0.25s", RegexCaptures(`took ([\d.]+)s`, ParsesAsFloat(Lt(1.0))))
+func ParsesAsFloat(inner any) Matcher {
+	return parsingMatcher{
+		inner: AsMatcher(inner),
+		kind:  "a float",
+		parse: func(s string) (any, error) { return strconv.ParseFloat(s, 64) },
+	}
+}
+
+type parsingMatcher struct {
+	stringMatcher
+	inner Matcher
+	kind  string
+	parse func(string) (any, error)
+}
+
+func (m parsingMatcher) Matches(x any) bool {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return false
+	}
+	parsed, err := m.parse(asStr)
+	if err != nil {
+		return false
+	}
+	return m.inner.Matches(parsed)
+}
+
+func (m parsingMatcher) String() string {
+	return fmt.Sprintf("when parsed as %s, %s", m.kind, m.inner.String())
+}
+
+func (m parsingMatcher) ExplainFailure(x any) (string, bool) {
+	asStr, ok := m.getString(x)
+	if !ok {
+		return m.stringMatcher.ExplainFailure(x)
+	}
+	parsed, err := m.parse(asStr)
+	if err != nil {
+		return fmt.Sprintf("value isn't %s: %s", m.kind, err), true
+	}
+	explanation := fmt.Sprintf("parsed value is %s", formatGot(parsed, m.inner))
+	explanation = appendExplanation(explanation, m.inner, parsed)
+	return explanation, true
 }
 
 // Utility mixin for string matchers. All matchers that embed this type
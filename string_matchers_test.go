@@ -1,8 +1,10 @@
 package gotest
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"unicode"
 )
 
 func TestSubstr(t *testing.T) {
@@ -98,4 +100,528 @@ func TestRegex(t *testing.T) {
 			"  ...where value is of type int, not a string",
 		}, "\n"))
 	})
+
+	t.Run("Options", func(t *testing.T) {
+		ExpectThat(t, "Hello", Regex("hello", IgnoreCase()))
+		ExpectThat(t, "Hello", Not(Regex("hello")))
+		ExpectThat(t, "say HELLO", ContainsRegex("hello", IgnoreCase()))
+		ExpectThat(t, "a\nb", Regex("a.b", DotAll()))
+		ExpectThat(t, "a\nb", Not(Regex("a.b")))
+		ExpectThat(t, "ok\nerror: boom", ContainsRegex("^error:", MultiLine()))
+		ExpectThat(t, "ok\nerror: boom", Not(ContainsRegex("^error:")))
+		ExpectThat(t, "a\nB", Regex("a$\n^b", IgnoreCase(), MultiLine()))
+
+		// Regex() still has to match the whole value in multi-line mode
+		ExpectThat(t, "a\nb", Not(Regex("a", MultiLine())))
+		ExpectThat(t, "a\nb", Not(Regex("b", MultiLine())))
+		ExpectThat(t, "a\nb", Regex("a\nb", MultiLine()))
+
+		r := testReporter{}
+		ExpectThat(&r, "Goodbye", Regex("hello", IgnoreCase(), DotAll(), MultiLine()))
+		ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+			"Expectation failed:",
+			"  Wanted: matches regex '^hello$' (case-insensitive, dot matches newline, multi-line)",
+			"  Got: Goodbye (string)",
+		}, "\n"))
+
+		r.Reset()
+		ExpectThat(&r, "Goodbye", ContainsRegex("hello", IgnoreCase()))
+		ExpectThat(t, r.nonFatals[0], HasSubstr("Wanted: matches regex 'hello' (case-insensitive)"))
+	})
+}
+
+func TestEqualIgnoringCase(t *testing.T) {
+	ExpectThat(t, "Hello", EqualIgnoringCase("hello"))
+	ExpectThat(t, "HELLO", EqualIgnoringCase("hello"))
+	ExpectThat(t, []byte("Hello"), EqualIgnoringCase("hELLO"))
+	ExpectThat(t, "ΣΑΣ", EqualIgnoringCase("σας"))
+	ExpectThat(t, "", EqualIgnoringCase(""))
+	ExpectThat(t, "hello", Not(EqualIgnoringCase("help")))
+	ExpectThat(t, "hello", Not(EqualIgnoringCase("hello!")))
+	ExpectThat(t, 12, Not(EqualIgnoringCase("12")))
+
+	r := testReporter{}
+	ExpectThat(&r, "Hello, World", EqualIgnoringCase("hello, word"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to 'hello, word' (ignoring case)",
+		"  Got: Hello, World (string)",
+		"  ...where first difference is at character 10 ('l' vs. 'd')",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "héllo", EqualIgnoringCase("HÉLLO!"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is too short; it ends at character 5"))
+
+	r.Reset()
+	ExpectThat(&r, "hello!", EqualIgnoringCase("HELLO"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is too long; it has extra text at character 5"))
+
+	r.Reset()
+	ExpectThat(&r, 12, EqualIgnoringCase("12"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+func TestEqualIgnoringWhitespace(t *testing.T) {
+	ExpectThat(t, "SELECT *\n  FROM users ", EqualIgnoringWhitespace("SELECT * FROM users"))
+	ExpectThat(t, "a  b", EqualIgnoringWhitespace(" a\tb\n"))
+	ExpectThat(t, []byte("a\r\nb"), EqualIgnoringWhitespace("a b"))
+	ExpectThat(t, "   ", EqualIgnoringWhitespace(""))
+	ExpectThat(t, "ab", Not(EqualIgnoringWhitespace("a b")))
+	ExpectThat(t, 12, Not(EqualIgnoringWhitespace("12")))
+
+	r := testReporter{}
+	ExpectThat(&r, "SELECT *\n  FROM  users", EqualIgnoringWhitespace("SELECT * FROM groups"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to 'SELECT * FROM groups' (ignoring whitespace)",
+		"  Got: SELECT *\n  FROM  users (string)",
+		"  ...where normalized value is 'SELECT * FROM users', but wanted 'SELECT * FROM groups'",
+	}, "\n"))
+}
+
+func TestEqualTrimmed(t *testing.T) {
+	ExpectThat(t, "  hello\n", EqualTrimmed("hello"))
+	ExpectThat(t, "hello", EqualTrimmed("\thello "))
+	ExpectThat(t, []byte(" hello world "), EqualTrimmed("hello world"))
+	ExpectThat(t, "hello  world", Not(EqualTrimmed("hello world")))
+	ExpectThat(t, 12, Not(EqualTrimmed("12")))
+
+	r := testReporter{}
+	ExpectThat(&r, " hello  world\n", EqualTrimmed("hello world"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to 'hello world' (ignoring leading and trailing whitespace)",
+		"  Got:  hello  world\n (string)",
+		"  ...where normalized value is 'hello  world', but wanted 'hello world'",
+	}, "\n"))
+}
+
+func TestMatchesGlob(t *testing.T) {
+	ExpectThat(t, "logs/app.log", MatchesGlob("logs/*.log"))
+	ExpectThat(t, "logs/.log", MatchesGlob("logs/*.log"))
+	ExpectThat(t, "file1.txt", MatchesGlob("file?.txt"))
+	ExpectThat(t, "file1.txt", MatchesGlob("file[0-9].txt"))
+	ExpectThat(t, "fileA.txt", MatchesGlob("file[^0-9].txt"))
+	ExpectThat(t, "a*b", MatchesGlob(`a\*b`))
+	ExpectThat(t, []byte("main.go"), MatchesGlob("*.go"))
+
+	ExpectThat(t, "logs/old/app.log", Not(MatchesGlob("logs/*.log")))
+	ExpectThat(t, "logs/app.log.1", Not(MatchesGlob("logs/*.log")))
+	ExpectThat(t, "file10.txt", Not(MatchesGlob("file?.txt")))
+	ExpectThat(t, "axb", Not(MatchesGlob(`a\*b`)))
+	ExpectThat(t, 12, Not(MatchesGlob("*")))
+
+	ExpectFatal(t, HasSubstr("invalid pattern '[a-'"), func() {
+		MatchesGlob("[a-")
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, "logs/app.txt", MatchesGlob("logs/*.log"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: matches glob 'logs/*.log'",
+		"  Got: logs/app.txt (string)",
+	}, "\n"))
+}
+
+func TestRegexCaptures(t *testing.T) {
+	ExpectThat(t, "login user=123", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+	ExpectThat(t, "login user=123", RegexCaptures(`user=(\d+)`, "123"))
+	ExpectThat(t, "login user=123", RegexCaptures(`user=(\d+)`, Len(3)))
+	ExpectThat(t, "a=1, b=x", RegexCaptures(`a=(\d), b=(\w)`, "1", "x"))
+	ExpectThat(t, "a=1, b=x", RegexCaptures(`a=(\d), b=(\w)`, Any()))
+	ExpectThat(t, "t=1.5", RegexCaptures(`t=([\d.]+)`, ParsesAsFloat(Near(1.5, 0.01))))
+	ExpectThat(t, []byte("id=7"), RegexCaptures(`id=(\d+)`, ParsesAsInt(7)))
+	ExpectThat(t, "anything", RegexCaptures(`\w+`))
+
+	ExpectThat(t, "user=42", Not(RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100)))))
+	ExpectThat(t, "user=abc", Not(RegexCaptures(`user=(\d+)`, Any())))
+	// Groups are only compared as numbers when asked to, so negations hold.
+	ExpectThat(t, "v=42", Not(RegexCaptures(`v=(\d+)`, Not("42"))))
+	ExpectThat(t, "v=42", RegexCaptures(`v=(\d+)`, Not(42)))
+	ExpectThat(t, "v=42", Not(RegexCaptures(`v=(\d+)`, ParsesAsInt(Not(42)))))
+	ExpectThat(t, 12, Not(RegexCaptures(`(\d+)`, Any())))
+
+	ExpectFatal(t, HasSubstr("has 1 capture groups, but 2 matchers were given"), func() {
+		RegexCaptures(`(\d+)`, 1, 2)
+	})
+
+	r := testReporter{}
+	ExpectThat(&r, "a=1, b=x", RegexCaptures(`a=(\d), b=(\w)`, ParsesAsInt(Gt(5)), Len(2)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		`  Wanted: matches regex 'a=(\d), b=(\w)' with groups [` +
+			"when parsed as an int, is greater than 5 (int); " +
+			"has length which is equal to 2 (int)]",
+		"  Got: a=1, b=x (string)",
+		"  ...where group 1 is '1': parsed value is 1 (int); group 2 is 'x': length is 1",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "user=abc", RegexCaptures(`user=(\d+)`, Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where regex doesn't match"))
+
+	r.Reset()
+	ExpectThat(&r, 12, RegexCaptures(`(\d+)`, Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+func TestParsesAsNumber(t *testing.T) {
+	ExpectThat(t, "123", ParsesAsInt(Gt(100)))
+	ExpectThat(t, []byte("-7"), ParsesAsInt(-7))
+	ExpectThat(t, "1.5", ParsesAsFloat(Near(1.5, 0.01)))
+	ExpectThat(t, "2e3", ParsesAsFloat(2000.0))
+	ExpectThat(t, "1.5", Not(ParsesAsInt(Any())))
+	ExpectThat(t, "abc", Not(ParsesAsFloat(Any())))
+	ExpectThat(t, 12, Not(ParsesAsInt(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, "42", ParsesAsInt(Gt(100)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when parsed as an int, is greater than 100 (int)",
+		"  Got: 42 (string)",
+		"  ...where parsed value is 42 (int)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "x", ParsesAsFloat(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr(`...where value isn't a float: strconv.ParseFloat: parsing "x": invalid syntax`))
+}
+
+func TestLinesEq(t *testing.T) {
+	ExpectThat(t, "a\nb\nc", LinesEq("a\nb\nc"))
+	ExpectThat(t, []byte("a\nb"), LinesEq("a\nb"))
+	ExpectThat(t, "a\nB\nc", Not(LinesEq("a\nb\nc")))
+	ExpectThat(t, "a\nb\nc\n", Not(LinesEq("a\nb\nc")))
+	ExpectThat(t, 12, Not(LinesEq("12")))
+
+	want := strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}, "\n")
+	got := strings.Join([]string{"1", "2", "3", "four", "5", "6", "7", "8", "9", "10", "11"}, "\n")
+	r := testReporter{}
+	ExpectThat(&r, got, LinesEq(want))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to the 10 lines starting with '1'",
+		"  Got: " + got + " (string)",
+		"  ...where lines differ (-want +got):",
+		"       2 | 2",
+		"       3 | 3",
+		"  -    4 | 4",
+		"  +    4 | four",
+		"       5 | 5",
+		"       6 | 6",
+		"    ...",
+		"       9 | 9",
+		"      10 | 10",
+		"  +   11 | 11",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "x", LinesEq("y"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to 'y'",
+		"  Got: x (string)",
+		"  ...where lines differ (-want +got):",
+		"  -    1 | y",
+		"  +    1 | x",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 12, LinesEq("12"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+// Texts too different to diff minimally within maxDiffCells are shown as
+// removed and then added, from the first difference.
+func TestDiffLines_Large(t *testing.T) {
+	want := []string{"same"}
+	got := []string{"same"}
+	for i := range 1100 {
+		want = append(want, fmt.Sprintf("w%d", i))
+		got = append(got, fmt.Sprintf("g%d", i))
+	}
+	want = append(want, "end")
+	got = append(got, "end")
+
+	lines := diffLines(want, got)
+	ExpectEq(t, len(lines), 2202)
+	ExpectEq(t, lines[0], diffLine{' ', 1, "same"})
+	ExpectEq(t, lines[1], diffLine{'-', 2, "w0"})
+	ExpectEq(t, lines[1100], diffLine{'-', 1101, "w1099"})
+	ExpectEq(t, lines[1101], diffLine{'+', 2, "g0"})
+	ExpectEq(t, lines[2201], diffLine{' ', 1102, "end"})
+}
+
+func TestContainsInOrder(t *testing.T) {
+	logs := "starting\nconnected to db\nserving\nshutting down"
+	ExpectThat(t, logs, ContainsInOrder("starting", "connected", "shutting down"))
+	ExpectThat(t, []byte(logs), ContainsInOrder("db", "serving"))
+	ExpectThat(t, "abcabc", ContainsInOrder("c", "a"))
+	ExpectThat(t, "aa", ContainsInOrder("a", "a"))
+	ExpectThat(t, "anything", ContainsInOrder())
+
+	ExpectThat(t, "abc", Not(ContainsInOrder("b", "a")))
+	ExpectThat(t, "aba", Not(ContainsInOrder("aba", "a")))
+	ExpectThat(t, "a", Not(ContainsInOrder("a", "a")))
+	ExpectThat(t, 12, Not(ContainsInOrder("1")))
+
+	r := testReporter{}
+	ExpectThat(&r, "one two three", ContainsInOrder("one", "three", "two"))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: contains substrings in order ['one', 'three', 'two']",
+		"  Got: one two three (string)",
+		"  ...where substring 2 ('two') wasn't found after character 13, where substring 1 ended",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "one two three", ContainsInOrder("four"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where substring 0 ('four') wasn't found"))
+
+	r.Reset()
+	ExpectThat(&r, 12, ContainsInOrder("1"))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+func TestEqNFC(t *testing.T) {
+	composed := "Caf\u00e9"
+	decomposed := "Cafe\u0301"
+	ExpectThat(t, decomposed, Not(Eq(composed))) // sanity check
+	ExpectThat(t, decomposed, EqNFC(composed))
+	ExpectThat(t, composed, EqNFC(decomposed))
+	ExpectThat(t, []byte(decomposed), EqNFC(composed))
+	ExpectThat(t, "\u212b", EqNFC("\u00c5")) // Angstrom sign vs. A with ring
+
+	ExpectThat(t, "Cafe", Not(EqNFC(composed)))
+	ExpectThat(t, "\ufb01", Not(EqNFC("fi"))) // Compatibility, not canonical, equivalence
+	ExpectThat(t, 12, Not(EqNFC("12")))
+
+	r := testReporter{}
+	ExpectThat(&r, "Cafe", EqNFC(decomposed))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is equal to '" + decomposed + "' (after Unicode normalization)",
+		"  Got: Cafe (string)",
+		"  ...where normalized value is 'Cafe', but wanted '" + composed + "'",
+	}, "\n"))
+}
+
+func TestRunes(t *testing.T) {
+	isDigit := Untyped(MatcherFuncT("is a digit", unicode.IsDigit))
+	isUpper := Untyped(MatcherFuncT("is upper case", unicode.IsUpper))
+	ExpectThat(t, "12345", Runes(Each(isDigit)))
+	ExpectThat(t, "hello World", Runes(Contains(isUpper)))
+	ExpectThat(t, "héllo", Runes(ElementsAre('h', 'é', 'l', 'l', 'o')))
+	ExpectThat(t, []byte("héllo"), Runes(Len(5)))
+	ExpectThat(t, "", Runes(Empty()))
+	ExpectThat(t, "12a45", Not(Runes(Each(isDigit))))
+	ExpectThat(t, "hello", Not(Runes(Contains(isUpper))))
+	ExpectThat(t, 12, Not(Runes(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, "12a", Runes(Each(isDigit)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: has runes which each element is a digit",
+		"  Got: 12a (string)",
+		"  ...where runes are ['1' '2' 'a'], where element 2 is 97 (int32)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 12, Runes(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+func TestBase64DecodesTo(t *testing.T) {
+	ExpectThat(t, "aGVsbG8=", Base64DecodesTo([]byte("hello")))
+	ExpectThat(t, "aGVsbG8", Base64DecodesTo([]byte("hello")))
+	ExpectThat(t, []byte("aGVsbG8="), Base64DecodesTo(HasSubstr("ell")))
+	ExpectThat(t, "-_8", Base64DecodesTo([]byte{0xfb, 0xff}))
+	ExpectThat(t, "+/8=", Base64DecodesTo([]byte{0xfb, 0xff}))
+	ExpectThat(t, "eyJzdWIiOiJhbGljZSJ9", Base64DecodesTo(JSONEq(`{"sub": "alice"}`)))
+	ExpectThat(t, "", Base64DecodesTo(Len(0)))
+
+	ExpectThat(t, "aGVsbG8=", Not(Base64DecodesTo([]byte("world"))))
+	ExpectThat(t, "not base64!", Not(Base64DecodesTo(Any())))
+	ExpectThat(t, 12, Not(Base64DecodesTo(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, "aGVsbG8=", Base64DecodesTo(Len(3)))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when base64-decoded, has length which is equal to 3 (int)",
+		"  Got: aGVsbG8= (string)",
+		"  ...where decoded value is [104 101 108 108 111] ([]uint8), where length is 5",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "abc!", Base64DecodesTo(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value isn't valid base64: illegal base64 data at input byte 3"))
+
+	r.Reset()
+	ExpectThat(&r, 12, Base64DecodesTo(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
+}
+
+func TestHexDecodesTo(t *testing.T) {
+	ExpectThat(t, "cafe", HexDecodesTo([]byte{0xca, 0xfe}))
+	ExpectThat(t, "CAFE", HexDecodesTo([]byte{0xca, 0xfe}))
+	ExpectThat(t, []byte("68656c6c6f"), HexDecodesTo([]byte("hello")))
+	ExpectThat(t, "68656c6c6f", HexDecodesTo(Len(5)))
+
+	ExpectThat(t, "cafe", Not(HexDecodesTo([]byte{0xbe, 0xef})))
+	ExpectThat(t, "caf", Not(HexDecodesTo(Any())))
+	ExpectThat(t, "zz", Not(HexDecodesTo(Any())))
+
+	r := testReporter{}
+	ExpectThat(&r, "cafe", HexDecodesTo([]byte{0xbe, 0xef}))
+	ExpectThat(t, r.nonFatals[0], StartsWith(strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: when hex-decoded, is equal to [190 239] ([]uint8)",
+		"  Got: cafe (string)",
+		"  ...where decoded value is [202 254] ([]uint8), where ",
+	}, "\n")))
+
+	r.Reset()
+	ExpectThat(&r, "zz", HexDecodesTo(Any()))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value isn't valid hex: encoding/hex: invalid byte: U+007A 'z'"))
+}
+
+func TestValidUTF8(t *testing.T) {
+	ExpectThat(t, "hello", ValidUTF8())
+	ExpectThat(t, "", ValidUTF8())
+	ExpectThat(t, "héllo, 世界", ValidUTF8())
+	ExpectThat(t, []byte("\uFFFD"), ValidUTF8()) // The replacement character itself is valid
+
+	ExpectThat(t, "h\xffi", Not(ValidUTF8()))
+	ExpectThat(t, []byte{0xe4, 0xb8}, Not(ValidUTF8()))
+	ExpectThat(t, 12, Not(ValidUTF8()))
+
+	r := testReporter{}
+	ExpectThat(&r, "héllo\xc3(", ValidUTF8())
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is valid UTF-8",
+		"  Got: héllo\xc3( (string)",
+		"  ...where byte 6 (0xc3) isn't part of a valid UTF-8 sequence",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, 12, ValidUTF8())
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where value is of type int, not a string"))
+
+	// Numeric matchers don't match the captured text, which is a string, but
+	// the explanation says how to compare it as a number.
+	r.Reset()
+	ExpectThat(&r, "user=150", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("...where group 1 is '150': doesn't match "+
+		"(the captured text is a string; wrap the matcher with ParsesAsInt() to compare it as a number)"))
+	ExpectThat(t, "user=150", RegexCaptures(`user=(\d+)`, ParsesAsInt(Gt(100))))
+
+	r.Reset()
+	ExpectThat(&r, "t=1.5", RegexCaptures(`t=([\d.]+)`, Lt(2.0)))
+	ExpectThat(t, r.nonFatals[0], HasSubstr("wrap the matcher with ParsesAsFloat()"))
+
+	r.Reset()
+	ExpectThat(&r, "user=50", RegexCaptures(`user=(\d+)`, Gt(100)))
+	ExpectThat(t, r.nonFatals[0], Not(HasSubstr("wrap the matcher")))
 }
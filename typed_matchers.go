@@ -0,0 +1,137 @@
+package gotest
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Matcher that only accepts values of type T.
+//
+// This is a statically-typed counterpart to Matcher. Using it with
+// ExpectThatT() and AssertThatT() means that mismatches between the type of
+// the value and the type of the expectation are compile errors, rather than
+// silent runtime failures.
+//
+// Any MatcherT can be converted to a Matcher with Untyped(), and any Matcher
+// (or plain value) can be converted to a MatcherT with AsMatcherT().
+type MatcherT[T any] interface {
+	Matches(x T) bool
+	String() string
+}
+
+// Like Eq, but statically typed - the value being tested must be of the same
+// type as `x`.
+//
+// Examples:
+//
+//	ExpectThatT(t, 5, EqT(5))    // succeeds
+//	ExpectThatT(t, 5, EqT("5"))  // compile error
+func EqT[T any](x T) MatcherT[T] {
+	return typedMatcher[T]{Eq(x)}
+}
+
+// Like MatcherFunc, but statically typed.
+//
+// Example:
+//
+//	isEven := MatcherFuncT("is even", func(i int) bool { return i%2 == 0 })
+//	ExpectThatT(t, 4, isEven)
+func MatcherFuncT[T any](desc string, f func(T) bool) MatcherT[T] {
+	return funcMatcherT[T]{desc, f}
+}
+
+// Converts `x` into a MatcherT[T].
+//
+// If `x` is already a MatcherT[T], it's returned unchanged. Otherwise, it's
+// converted to a Matcher using AsMatcher(), and values of type T are passed
+// through to that matcher.
+//
+// Example:
+//
+//	ExpectThatT(t, "hello", AsMatcherT[string](HasSubstr("ell")))
+func AsMatcherT[T any](x any) MatcherT[T] {
+	if alreadyTyped, ok := x.(MatcherT[T]); ok {
+		return alreadyTyped
+	}
+	return typedMatcher[T]{AsMatcher(x)}
+}
+
+// Converts a MatcherT[T] into a Matcher, so that it can be used with
+// ExpectThat(), gomock, or nested inside other matchers.
+//
+// The resulting matcher never matches values that aren't of type T.
+//
+// Example:
+//
+//	ExpectThat(t, []int{1, 2}, ElementsAre(Untyped(EqT(1)), 2))
+func Untyped[T any](m MatcherT[T]) Matcher {
+	if wrapper, ok := m.(typedMatcher[T]); ok {
+		return wrapper.inner
+	}
+	return untypedMatcher[T]{m}
+}
+
+type funcMatcherT[T any] struct {
+	desc string
+	f    func(T) bool
+}
+
+func (m funcMatcherT[T]) Matches(x T) bool {
+	return m.f(x)
+}
+
+func (m funcMatcherT[T]) String() string {
+	return m.desc
+}
+
+// Adapts a Matcher into a MatcherT[T].
+type typedMatcher[T any] struct {
+	inner Matcher
+}
+
+func (m typedMatcher[T]) Matches(x T) bool {
+	return m.inner.Matches(x)
+}
+
+func (m typedMatcher[T]) String() string {
+	return m.inner.String()
+}
+
+// Adapts a MatcherT[T] into a Matcher.
+type untypedMatcher[T any] struct {
+	inner MatcherT[T]
+}
+
+// Converts `x` to T. Untyped nil is the zero value of nillable types, such as
+// a nil error.
+func (m untypedMatcher[T]) convert(x any) (T, bool) {
+	if asT, ok := x.(T); ok {
+		return asT, true
+	}
+	var zero T
+	return zero, x == nil && isNillable(reflect.TypeFor[T]())
+}
+
+func (m untypedMatcher[T]) Matches(x any) bool {
+	if asT, ok := m.convert(x); ok {
+		return m.inner.Matches(asT)
+	}
+	return false
+}
+
+func (m untypedMatcher[T]) String() string {
+	return m.inner.String()
+}
+
+func (m untypedMatcher[T]) ExplainFailure(x any) (string, bool) {
+	asT, ok := m.convert(x)
+	if !ok {
+		return fmt.Sprintf("value is of type %T, not %s", x, reflect.TypeFor[T]()), true
+	}
+	if explainer, ok := m.inner.(interface {
+		ExplainFailure(val T) (string, bool)
+	}); ok {
+		return explainer.ExplainFailure(asT)
+	}
+	return "", false
+}
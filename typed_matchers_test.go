@@ -0,0 +1,66 @@
+package gotest
+
+import (
+	"strings"
+	"testing"
+)
+
+type evenT struct{}
+
+func (evenT) Matches(x int) bool { return x%2 == 0 }
+func (evenT) String() string     { return "is even" }
+
+func TestTypedMatchers(t *testing.T) {
+	ExpectThatT(t, 5, EqT(5))
+	ExpectThatT(t, "hello", EqT("hello"))
+	ExpectThatT(t, []int{1, 2}, EqT([]int{1, 2}))
+	ExpectThatT(t, "hello", AsMatcherT[string](HasSubstr("ell")))
+	ExpectThatT(t, "hello", AsMatcherT[string]("hello"))
+	ExpectThatT(t, 4, evenT{})
+
+	// Typed matchers can be nested inside untyped ones
+	ExpectThat(t, []int{2, 3}, ElementsAre(Untyped[int](evenT{}), 3))
+	ExpectThat(t, 3, Not(Untyped[int](evenT{})))
+	ExpectThat(t, "2", Not(Untyped[int](evenT{})))
+
+	// Round trips preserve the original matcher
+	var m MatcherT[int] = evenT{}
+	ExpectEq(t, AsMatcherT[int](m), m)
+	ExpectEq(t, Untyped(AsMatcherT[string](HasSubstr("a"))), HasSubstr("a"))
+
+	r := testReporter{}
+	ExpectThatT(&r, 3, MatcherT[int](evenT{}))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is even",
+		"  Got: 3 (int)",
+	}, "\n"))
+
+	r.Reset()
+	ExpectThat(&r, "3", Untyped[int](evenT{}))
+	ExpectEq(t, r.nonFatals[0], strings.Join([]string{
+		"Expectation failed:",
+		"  Wanted: is even",
+		"  Got: 3 (string)",
+		"  ...where value is of type string, not int",
+	}, "\n"))
+
+	r.Reset()
+	AssertThatT(&r, "abc", EqT("abd"))
+	ExpectThat(t, r.fatals, ElementsAre(HasSubstr("Assertion failed:")))
+}
+
+func TestMatcherFuncT(t *testing.T) {
+	isEven := MatcherFuncT("is even", func(i int) bool { return i%2 == 0 })
+	ExpectThatT(t, 4, isEven)
+	ExpectThat(t, 3, Not(Untyped(isEven)))
+	ExpectThat(t, "4", Not(Untyped(isEven)))
+	ExpectEq(t, isEven.String(), "is even")
+
+	// Untyped nil is the zero value of nillable types.
+	isNilErr := MatcherFuncT("is nil", func(e error) bool { return e == nil })
+	ExpectThatT[error](t, nil, isNilErr)
+	ExpectThat(t, nil, Untyped(isNilErr))
+	ExpectThat(t, nil, Untyped(MatcherFuncT("is nil", func(s []int) bool { return s == nil })))
+	ExpectThat(t, nil, Not(Untyped(isEven)))
+}